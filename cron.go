@@ -0,0 +1,235 @@
+package timeapi
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// Cron represents a cron expression, such as "*/15 9-17 * * MON-FRI".
+// Use ParseCron to parse an expression and Cron.Next and Cron.Prev
+// to compute its fire times.
+//
+// An expression has five fields, minute, hour, day of month, month and
+// day of week, optionally preceded by a sixth field for the second.
+// If both the day of month and the day of week are restricted, that is,
+// neither of them is "*" or "?", a day matching any of them matches,
+// as in the original cron.
+//
+// The fields are matched against the wall clock time in a timezone.
+// A wall clock time skipped by a DST change doesn't fire, and a wall
+// clock time repeated by a DST change fires only once, at its first
+// occurrence. For example, "30 2 * * *" doesn't fire on the day
+// the clocks go from 02:00 to 03:00 in the America/New_York timezone.
+type Cron struct {
+	expr   string
+	second uint64
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+	// domOrDow is set if both the day of month and the day of week
+	// are restricted, so a day matching any of them matches.
+	domOrDow bool
+}
+
+// String returns the expression as it was parsed,
+// with the fields separated by single spaces.
+func (c Cron) String() string {
+	return c.expr
+}
+
+// Next returns the first fire time after dt, with the expression
+// evaluated in the timezone tz. It reports false if the expression
+// doesn't fire after dt and before the end of the year 9999.
+func (c Cron) Next(dt DateTime, tz Timezone) (DateTime, bool) {
+	loc := tz.GoLocation()
+	local := dt.t.In(loc)
+	hour, min, sec := local.Clock()
+
+	for day := daysFromCivil(local.Date()); ; day, hour, min, sec = day+1, 0, 0, 0 {
+		year, month, mday := civilFromDays(day)
+		if year > maxYear {
+			return DateTime{}, false
+		}
+		if c.month&(1<<month) == 0 {
+			// skip to the last day of the month
+			day += daysIn(year, month) - mday
+			continue
+		}
+		if !c.matchDay(day) {
+			continue
+		}
+		if t, ok := c.firstInDay(year, month, mday, hour, min, sec, loc, dt.t); ok {
+			return DateTime{t.UTC()}, true
+		}
+	}
+}
+
+// Prev returns the last fire time before dt, with the expression
+// evaluated in the timezone tz. It reports false if the expression
+// doesn't fire before dt and after the start of the year 1.
+func (c Cron) Prev(dt DateTime, tz Timezone) (DateTime, bool) {
+	loc := tz.GoLocation()
+	// the last fire time before dt may have a later wall clock time than dt,
+	// if dt is in the second occurrence of a wall clock time repeated
+	// by a DST change, so the search starts after any such repetition
+	local := dt.t.Add(3 * time.Hour).In(loc)
+	hour, min, sec := local.Clock()
+
+	for day := daysFromCivil(local.Date()); ; day, hour, min, sec = day-1, 23, 59, 59 {
+		year, month, mday := civilFromDays(day)
+		if year < 1 {
+			return DateTime{}, false
+		}
+		if c.month&(1<<month) == 0 {
+			// skip to the first day of the month
+			day -= mday - 1
+			continue
+		}
+		if !c.matchDay(day) {
+			continue
+		}
+		if t, ok := c.lastInDay(year, month, mday, hour, min, sec, loc, dt.t); ok {
+			return DateTime{t.UTC()}, true
+		}
+	}
+}
+
+// matchDay reports whether the day, as returned by daysFromCivil,
+// matches the day of month and the day of week fields.
+func (c Cron) matchDay(days int) bool {
+	_, _, mday := civilFromDays(days)
+	domOK := c.dom&(1<<mday) != 0
+	dowOK := c.dow&(1<<weekdayOf(days)) != 0
+	if c.domOrDow {
+		return domOK || dowOK
+	}
+	return domOK && dowOK
+}
+
+// firstInDay returns the first fire time after the instant after on the
+// day, starting from the wall clock time hour:min:sec.
+func (c Cron) firstInDay(year int, month time.Month, day, hour, min, sec int, loc *time.Location, after time.Time) (time.Time, bool) {
+	for h := hour; h < 24; h, min, sec = h+1, 0, 0 {
+		if c.hour&(1<<h) == 0 {
+			continue
+		}
+		for m := min; m < 60; m, sec = m+1, 0 {
+			if c.minute&(1<<m) == 0 {
+				continue
+			}
+			for s := sec; s < 60; s++ {
+				if c.second&(1<<s) == 0 {
+					continue
+				}
+				if t, ok := wallTime(year, month, day, h, m, s, loc); ok && t.After(after) {
+					return t, true
+				}
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// lastInDay returns the last fire time before the instant before on the
+// day, starting from the wall clock time hour:min:sec.
+func (c Cron) lastInDay(year int, month time.Month, day, hour, min, sec int, loc *time.Location, before time.Time) (time.Time, bool) {
+	for h := hour; h >= 0; h, min, sec = h-1, 59, 59 {
+		if c.hour&(1<<h) == 0 {
+			continue
+		}
+		for m := min; m >= 0; m, sec = m-1, 59 {
+			if c.minute&(1<<m) == 0 {
+				continue
+			}
+			for s := sec; s >= 0; s-- {
+				if c.second&(1<<s) == 0 {
+					continue
+				}
+				if t, ok := wallTime(year, month, day, h, m, s, loc); ok && t.Before(before) {
+					return t, true
+				}
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// wallTime returns the first instant at which the wall clock in loc
+// shows the given time. It reports false if the time is skipped
+// by a DST change.
+func wallTime(year int, month time.Month, day, hour, min, sec int, loc *time.Location) (time.Time, bool) {
+	wall := time.Date(year, month, day, hour, min, sec, 0, time.UTC).Unix()
+
+	// try the offsets in effect a day before and a day after
+	var first time.Time
+	found := false
+	for _, probe := range [...]int64{wall - 24*3600, wall + 24*3600} {
+		_, offset := time.Unix(probe, 0).In(loc).Zone()
+		t := time.Unix(wall-int64(offset), 0).In(loc)
+		if _, o := t.Zone(); o == offset && (!found || t.Before(first)) {
+			first, found = t, true
+		}
+	}
+	return first, found
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The zero Cron, which never fires, is written as "".
+func (c Cron) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + c.String() + `"`), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It accepts the expressions parsed by ParseCron and "" as the zero Cron.
+func (c *Cron) UnmarshalJSON(b []byte) error {
+	// strip quotes
+	if len(b) < 2 {
+		return NewErrJsonValue(fmt.Errorf("cron %q is invalid", string(b)))
+	}
+	b = b[1 : len(b)-1]
+	if len(b) == 0 {
+		*c = Cron{}
+		return nil
+	}
+
+	cron, err := ParseCron(string(b))
+	if err != nil {
+		return NewErrJsonValue(err)
+	}
+	*c = cron
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+// The expression is written as it was parsed.
+func (c Cron) Value() (driver.Value, error) {
+	return c.String(), nil
+}
+
+// Scan implements the sql.Scanner interface.
+// It accepts []byte and string values in the format parsed by ParseCron
+// and "" as the zero Cron. Use sql.Null[Cron] for nullable columns.
+func (c *Cron) Scan(src any) error {
+	switch v := src.(type) {
+	case []byte:
+		return c.Scan(string(v))
+	case string:
+		if v == "" {
+			*c = Cron{}
+			return nil
+		}
+		cron, err := ParseCron(v)
+		if err != nil {
+			return NewErrSqlValue(err)
+		}
+		*c = cron
+		return nil
+	case nil:
+		return NewErrSqlValue(fmt.Errorf("cron cannot be null"))
+	default:
+		return NewErrSqlValue(fmt.Errorf("cron cannot be scanned from %T", src))
+	}
+}
@@ -0,0 +1,233 @@
+package timeapi
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/krhubert/assert"
+)
+
+func TestCron(t *testing.T) {
+	utc := NewTimezone(*time.UTC)
+	loc, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+	ny := NewTimezone(*loc)
+
+	mustParse := func(t *testing.T, s string) Cron {
+		t.Helper()
+		c, err := ParseCron(s)
+		assert.NoError(t, err)
+		return c
+	}
+
+	t.Run("Next", func(t *testing.T) {
+		tests := []struct {
+			expr string
+			from DateTime
+			want DateTime
+		}{
+			{"*/15 * * * *", NewDateTime(2024, 1, 1, 10, 7, 0), NewDateTime(2024, 1, 1, 10, 15, 0)},
+			{"*/15 * * * *", NewDateTime(2024, 1, 1, 10, 15, 0), NewDateTime(2024, 1, 1, 10, 30, 0)},
+			{"*/15 * * * *", NewDateTime(2024, 12, 31, 23, 59, 59), NewDateTime(2025, 1, 1, 0, 0, 0)},
+			{"0 9 * * MON-FRI", NewDateTime(2024, 1, 5, 10, 0, 0), NewDateTime(2024, 1, 8, 9, 0, 0)},
+			{"0 9 * * monday", NewDateTime(2024, 1, 1, 9, 0, 0), NewDateTime(2024, 1, 8, 9, 0, 0)},
+			{"0 0 29 2 *", NewDateTime(2021, 3, 1, 0, 0, 0), NewDateTime(2024, 2, 29, 0, 0, 0)},
+			{"0 0 1 JAN,jul *", NewDateTime(2024, 2, 1, 0, 0, 0), NewDateTime(2024, 7, 1, 0, 0, 0)},
+			{"0 0 13 * FRI", NewDateTime(2024, 1, 1, 0, 0, 0), NewDateTime(2024, 1, 5, 0, 0, 0)},
+			{"0 0 13 * FRI", NewDateTime(2024, 1, 12, 0, 0, 0), NewDateTime(2024, 1, 13, 0, 0, 0)},
+			{"0 0 13 * *", NewDateTime(2024, 1, 12, 0, 0, 0), NewDateTime(2024, 1, 13, 0, 0, 0)},
+			{"0 0 ? * 7", NewDateTime(2024, 1, 1, 0, 0, 0), NewDateTime(2024, 1, 7, 0, 0, 0)},
+			{"0 0 * * 5/7", NewDateTime(2024, 1, 1, 0, 0, 0), NewDateTime(2024, 1, 5, 0, 0, 0)},
+			{"30 * * * * *", NewDateTime(2024, 1, 1, 0, 0, 30), NewDateTime(2024, 1, 1, 0, 1, 30)},
+			{"*/20 0 0 * * *", NewDateTime(2024, 1, 1, 0, 0, 20), NewDateTime(2024, 1, 1, 0, 0, 40)},
+			{"@daily", NewDateTime(2024, 1, 1, 0, 0, 0), NewDateTime(2024, 1, 2, 0, 0, 0)},
+			{"@hourly", NewDateTime(2024, 1, 1, 0, 30, 0), NewDateTime(2024, 1, 1, 1, 0, 0)},
+			{"@weekly", NewDateTime(2024, 1, 1, 0, 0, 0), NewDateTime(2024, 1, 7, 0, 0, 0)},
+			{"@MONTHLY", NewDateTime(2024, 1, 1, 0, 0, 0), NewDateTime(2024, 2, 1, 0, 0, 0)},
+			{"@yearly", NewDateTime(2024, 1, 1, 0, 0, 0), NewDateTime(2025, 1, 1, 0, 0, 0)},
+		}
+		for _, tt := range tests {
+			got, ok := mustParse(t, tt.expr).Next(tt.from, utc)
+			assert.True(t, ok)
+			assert.Equal(t, got, tt.want)
+		}
+
+		// in the timezone, 09:00 is 14:00 UTC in winter
+		got, ok := mustParse(t, "0 9 * * *").Next(NewDateTime(2024, 1, 1, 12, 0, 0), ny)
+		assert.True(t, ok)
+		assert.Equal(t, got, NewDateTime(2024, 1, 1, 14, 0, 0))
+
+		_, ok = mustParse(t, "0 0 30 2 *").Next(NewDateTime(2024, 1, 1, 0, 0, 0), utc)
+		assert.False(t, ok)
+		_, ok = Cron{}.Next(NewDateTime(2024, 1, 1, 0, 0, 0), utc)
+		assert.False(t, ok)
+	})
+
+	t.Run("Prev", func(t *testing.T) {
+		tests := []struct {
+			expr string
+			from DateTime
+			want DateTime
+		}{
+			{"*/15 * * * *", NewDateTime(2024, 1, 1, 10, 7, 0), NewDateTime(2024, 1, 1, 10, 0, 0)},
+			{"*/15 * * * *", NewDateTime(2024, 1, 1, 10, 0, 0), NewDateTime(2024, 1, 1, 9, 45, 0)},
+			{"*/15 * * * *", NewDateTime(2024, 1, 1, 0, 0, 0), NewDateTime(2023, 12, 31, 23, 45, 0)},
+			{"0 9 * * MON-FRI", NewDateTime(2024, 1, 8, 8, 0, 0), NewDateTime(2024, 1, 5, 9, 0, 0)},
+			{"0 0 29 2 *", NewDateTime(2024, 2, 28, 0, 0, 0), NewDateTime(2020, 2, 29, 0, 0, 0)},
+			{"0 0 1 JAN,JUL *", NewDateTime(2024, 6, 1, 0, 0, 0), NewDateTime(2024, 1, 1, 0, 0, 0)},
+			{"30 * * * * *", NewDateTime(2024, 1, 1, 0, 0, 30), NewDateTime(2023, 12, 31, 23, 59, 30)},
+		}
+		for _, tt := range tests {
+			got, ok := mustParse(t, tt.expr).Prev(tt.from, utc)
+			assert.True(t, ok)
+			assert.Equal(t, got, tt.want)
+		}
+
+		_, ok := mustParse(t, "0 0 30 2 *").Prev(NewDateTime(2024, 1, 1, 0, 0, 0), utc)
+		assert.False(t, ok)
+	})
+
+	t.Run("DST", func(t *testing.T) {
+		// 2024-03-10 02:00 EST is followed by 03:00 EDT,
+		// so 02:30 doesn't exist that day
+		c := mustParse(t, "30 2 * * *")
+		got, ok := c.Next(NewDateTime(2024, 3, 9, 8, 0, 0), ny)
+		assert.True(t, ok)
+		assert.Equal(t, got, NewDateTime(2024, 3, 11, 6, 30, 0))
+
+		got, ok = c.Prev(NewDateTime(2024, 3, 11, 4, 0, 0), ny)
+		assert.True(t, ok)
+		assert.Equal(t, got, NewDateTime(2024, 3, 9, 7, 30, 0))
+
+		// 2024-11-03 02:00 EDT is followed by 01:00 EST,
+		// so 01:30 happens twice that day and fires once
+		c = mustParse(t, "30 1 * * *")
+		got, ok = c.Next(NewDateTime(2024, 11, 3, 4, 0, 0), ny)
+		assert.True(t, ok)
+		assert.Equal(t, got, NewDateTime(2024, 11, 3, 5, 30, 0))
+
+		got, ok = c.Next(got, ny)
+		assert.True(t, ok)
+		assert.Equal(t, got, NewDateTime(2024, 11, 4, 6, 30, 0))
+
+		got, ok = c.Prev(NewDateTime(2024, 11, 3, 6, 45, 0), ny)
+		assert.True(t, ok)
+		assert.Equal(t, got, NewDateTime(2024, 11, 3, 5, 30, 0))
+
+		c = mustParse(t, "*/30 * * * *")
+		var fires []DateTime
+		for dt := NewDateTime(2024, 11, 3, 4, 45, 0); len(fires) < 4; {
+			dt, ok = c.Next(dt, ny)
+			assert.True(t, ok)
+			fires = append(fires, dt)
+		}
+		assert.Equal(t, fires, []DateTime{
+			NewDateTime(2024, 11, 3, 5, 0, 0),
+			NewDateTime(2024, 11, 3, 5, 30, 0),
+			NewDateTime(2024, 11, 3, 7, 0, 0),
+			NewDateTime(2024, 11, 3, 7, 30, 0),
+		})
+
+		got, ok = c.Prev(NewDateTime(2024, 11, 3, 7, 0, 0), ny)
+		assert.True(t, ok)
+		assert.Equal(t, got, NewDateTime(2024, 11, 3, 5, 30, 0))
+	})
+
+	t.Run("ParseCron", func(t *testing.T) {
+		c := mustParse(t, "  0 9 * * MON-FRI ")
+		assert.Equal(t, c.String(), "0 9 * * MON-FRI")
+		c = mustParse(t, "0 0\t* *\n *")
+		assert.Equal(t, c.String(), "0 0 * * *")
+		assert.Equal(t, mustParse(t, " @Daily ").String(), "@Daily")
+		assert.Equal(t, mustParse(t, "0 0 * * 0"), Cron{
+			expr:   "0 0 * * 0",
+			second: 1,
+			minute: 1,
+			hour:   1,
+			dom:    (1<<32 - 1) &^ 1,
+			month:  (1<<13 - 1) &^ 1,
+			dow:    1,
+		})
+		assert.Equal(t, mustParse(t, "0 0 * * 7").dow, uint64(1))
+		assert.Equal(t, mustParse(t, "0 0 * * SUN,sat").dow, uint64(1|1<<6))
+		assert.Equal(t, mustParse(t, "0 0 * * 1-5/2").dow, uint64(1<<1|1<<3|1<<5))
+		assert.Equal(t, mustParse(t, "10/20 * * * *").minute, uint64(1<<10|1<<30|1<<50))
+
+		errs := map[string]string{
+			"* * * *":       "must have 5 or 6 fields",
+			"* * * * * * *": "must have 5 or 6 fields",
+			"@every 1h":     "unknown cron macro",
+			"60 * * * *":    `invalid cron field "60"`,
+			"* 24 * * *":    `invalid cron field "24"`,
+			"* * 0 * *":     `invalid cron field "0"`,
+			"* * * 13 *":    `invalid cron field "13"`,
+			"* * * * 8":     `invalid cron field "8"`,
+			"*/0 * * * *":   "invalid cron field",
+			"5-1 * * * *":   "invalid cron field",
+			"a * * * *":     "invalid cron field",
+			"1,,2 * * * *":  "invalid cron field",
+			"-1 * * * *":    "invalid cron field",
+			"? * * * *":     "invalid cron field",
+			"* * * JAN-X *": "invalid cron field",
+		}
+		for s, want := range errs {
+			_, err := ParseCron(s)
+			assert.ErrorContains(t, err, want)
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		out, err := json.Marshal(mustParse(t, "@daily"))
+		assert.NoError(t, err)
+		assert.Equal(t, string(out), `"@daily"`)
+
+		out, err = json.Marshal(mustParse(t, "*/5\t*  * * *"))
+		assert.NoError(t, err)
+		assert.Equal(t, string(out), `"*/5 * * * *"`)
+
+		var c Cron
+		err = json.Unmarshal([]byte(`"*/5 * * * *"`), &c)
+		assert.NoError(t, err)
+		assert.Equal(t, c, mustParse(t, "*/5 * * * *"))
+
+		out, err = json.Marshal(Cron{})
+		assert.NoError(t, err)
+		assert.Equal(t, string(out), `""`)
+		err = json.Unmarshal(out, &c)
+		assert.NoError(t, err)
+		assert.Equal(t, c, Cron{})
+
+		err = json.Unmarshal([]byte(`"* * *"`), &c)
+		assert.ErrorContains(t, err, "must have 5 or 6 fields")
+		err = json.Unmarshal([]byte(`1`), &c)
+		assert.Error(t, err)
+	})
+
+	t.Run("Value", func(t *testing.T) {
+		v, err := mustParse(t, "0 9 * * 1-5").Value()
+		assert.NoError(t, err)
+		assert.Equal(t, v, driver.Value("0 9 * * 1-5"))
+	})
+
+	t.Run("Scan", func(t *testing.T) {
+		var c Cron
+		err := c.Scan([]byte("@hourly"))
+		assert.NoError(t, err)
+		assert.Equal(t, c, mustParse(t, "@hourly"))
+
+		v, err := Cron{}.Value()
+		assert.NoError(t, err)
+		err = c.Scan(v)
+		assert.NoError(t, err)
+		assert.Equal(t, c, Cron{})
+
+		err = c.Scan("* *")
+		assert.ErrorContains(t, err, "must have 5 or 6 fields")
+		err = c.Scan(nil)
+		assert.ErrorContains(t, err, "cannot be null")
+		err = c.Scan(int64(1))
+		assert.ErrorContains(t, err, "cannot be scanned from int64")
+	})
+}
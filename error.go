@@ -17,3 +17,21 @@ func (e ErrJsonValue) Error() string {
 func (e ErrJsonValue) Unwrap() error {
 	return e.err
 }
+
+// ErrSqlValue defines an error that occurs when a value
+// scanned from or written to a database is invalid.
+type ErrSqlValue struct {
+	err error
+}
+
+func NewErrSqlValue(err error) ErrSqlValue {
+	return ErrSqlValue{err: err}
+}
+
+func (e ErrSqlValue) Error() string {
+	return "timeapi: " + e.err.Error()
+}
+
+func (e ErrSqlValue) Unwrap() error {
+	return e.err
+}
@@ -6,7 +6,9 @@ package timeapi
 import (
 	"errors"
 	"math"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -59,11 +61,6 @@ func ParseDuration(s string) (Duration, error) {
 		return d, errors.New("timeapi: invalid duration " + strconv.Quote(orig))
 	}
 
-	d.neg = 1
-	if neg {
-		d.neg = -1
-	}
-
 	// Special case: if all that is left is "0", this is zero.
 	if s == "0" {
 		return d, nil
@@ -134,6 +131,129 @@ func ParseDuration(s string) (Duration, error) {
 			return d, errors.New("timeapi: invalid duration " + strconv.Quote(orig))
 		}
 	}
+	// zero is never negative, so "-0s" is the same as "0s"
+	d.neg = neg && !d.IsZero()
+	if neg {
+		return d, nil
+	}
+	if dtmp > 1<<63-1 {
+		return d, errors.New("timeapi: invalid duration " + strconv.Quote(orig))
+	}
+	return d, nil
+}
+
+var isoDurationUnitRank = map[byte]int{
+	'H': 0,
+	'M': 1,
+	'S': 2,
+}
+
+// ParseDurationISO parses an ISO 8601 duration string and returns
+// a Duration, such as "PT300S", "-PT1H" or "PT2H45M".
+// Valid time units are "H", "M" and "S", all following the "T" designator.
+// This implementation disallows:
+//   - of "Y", "M", "W" and "D" units before the "T" designator.
+//   - of signs on individual units.
+//   - of fractions of a unit.
+//   - repeating a unit.
+//   - units out of order.
+func ParseDurationISO(s string) (Duration, error) {
+	// [-+]?PT([0-9]+[HMS])+
+	orig := s
+	var dtmp uint64
+	var d Duration
+
+	maxRank := -1
+	neg := false
+
+	// Consume [-+]?
+	if s != "" {
+		c := s[0]
+		if c == '-' || c == '+' {
+			neg = c == '-'
+			s = s[1:]
+		}
+	}
+
+	// Consume P
+	if s == "" || s[0] != 'P' {
+		return d, errors.New("timeapi: invalid duration " + strconv.Quote(orig))
+	}
+	s = s[1:]
+
+	// Consume T, anything else is a date component.
+	if s == "" {
+		return d, errors.New("timeapi: invalid duration " + strconv.Quote(orig))
+	}
+	if s[0] != 'T' {
+		_, rem, _ := leadingInt(s)
+		if rem != "" && strings.IndexByte("YMWD", rem[0]) >= 0 {
+			return d, errors.New("timeapi: unit " + strconv.Quote(rem[:1]) + " not allowed in duration " + strconv.Quote(orig))
+		}
+		return d, errors.New("timeapi: invalid duration " + strconv.Quote(orig))
+	}
+	s = s[1:]
+
+	if s == "" {
+		return d, errors.New("timeapi: invalid duration " + strconv.Quote(orig))
+	}
+
+	for s != "" {
+		var v uint64
+		var err error
+
+		// The next character must be [0-9]
+		if !('0' <= s[0] && s[0] <= '9') {
+			return d, errors.New("timeapi: invalid duration " + strconv.Quote(orig))
+		}
+		// Consume [0-9]*
+		v, s, err = leadingInt(s)
+		if err != nil {
+			return d, errors.New("timeapi: invalid duration " + strconv.Quote(orig))
+		}
+
+		// Consume unit.
+		if s == "" {
+			return d, errors.New("timeapi: missing unit in duration " + strconv.Quote(orig))
+		}
+		u := s[:1]
+		s = s[1:]
+
+		rank, ok := isoDurationUnitRank[u[0]]
+		if !ok {
+			return d, errors.New("timeapi: unknown unit " + strconv.Quote(u) + " in duration " + strconv.Quote(orig))
+		}
+		if rank == maxRank {
+			return d, errors.New("timeapi: unit " + strconv.Quote(u) + " repeated in duration " + strconv.Quote(orig))
+		}
+		if rank < maxRank {
+			return d, errors.New("timeapi: unit " + strconv.Quote(u) + " must be in the order of H, M, S in duration " + strconv.Quote(orig))
+		}
+		maxRank = rank
+
+		unit := durationUnitMap[strings.ToLower(u)]
+		if v > 1<<63/unit {
+			// overflow
+			return d, errors.New("timeapi: invalid duration " + strconv.Quote(orig))
+		}
+
+		switch u {
+		case "H":
+			d.hour = int(v)
+		case "M":
+			d.minute = int(v)
+		case "S":
+			d.second = int(v)
+		}
+		v *= unit
+
+		dtmp += v
+		if dtmp > 1<<63 {
+			return d, errors.New("timeapi: invalid duration " + strconv.Quote(orig))
+		}
+	}
+	// zero is never negative, so "-0s" is the same as "0s"
+	d.neg = neg && !d.IsZero()
 	if neg {
 		return d, nil
 	}
@@ -153,11 +273,19 @@ var intervalUnitRank = map[string]int{
 }
 
 // ParseInterval parses a string and returns an Interval.
-// A interval string is a sequence of decimal numbers,
-// each with a unit suffix, such as "1y", "1mo" or "2h45m".
-// Valid units are "y", "mo", "d", "h", "m", "s".
+// A interval string is a sequence of optionally signed decimal numbers,
+// each with a unit suffix, such as "1y", "-1mo" or "+1mo-1d":
+//
+//	interval  = "0" | component { component }
+//	component = [ "+" | "-" ] digit { digit } unit
+//	unit      = "y" | "mo" | "d" | "h" | "m" | "s"
+//
+// A sign applies to its own component only, so "-1mo2d" is one month
+// back and two days forward. Units must be in the order of the unit
+// rule and can't be repeated. Every Interval, as printed by
+// Interval.String, is parsed back to the same value.
 func ParseInterval(s string) (Interval, error) {
-	// ([0-9]*[a-z]+)+
+	// ([-+]?[0-9]*[a-z]+)+
 	orig := s
 	seen := map[string]bool{
 		"y":  false,
@@ -183,8 +311,15 @@ func ParseInterval(s string) (Interval, error) {
 		var v uint64
 		var err error
 
+		// Consume [-+]?
+		neg := false
+		if c := s[0]; c == '-' || c == '+' {
+			neg = c == '-'
+			s = s[1:]
+		}
+
 		// The next character must be [0-9]
-		if !('0' <= s[0] && s[0] <= '9') {
+		if s == "" || !('0' <= s[0] && s[0] <= '9') {
 			return ivl, errors.New("timeapi: invalid interval " + strconv.Quote(orig))
 		}
 		// Consume [0-9]*
@@ -197,7 +332,7 @@ func ParseInterval(s string) (Interval, error) {
 		i := 0
 		for ; i < len(s); i++ {
 			c := s[i]
-			if '0' <= c && c <= '9' {
+			if '0' <= c && c <= '9' || c == '-' || c == '+' {
 				break
 			}
 		}
@@ -225,29 +360,338 @@ func ParseInterval(s string) (Interval, error) {
 		maxRank = max(seenRank, maxRank)
 		seen[u] = true
 
-		if v > math.MaxInt {
+		n, ok := signedInt(v, neg)
+		if !ok {
+			// overflow
+			return ivl, errors.New("timeapi: invalid interval " + strconv.Quote(orig))
+		}
+
+		switch u {
+		case "y":
+			ivl.year = n
+		case "mo":
+			ivl.month = n
+		case "d":
+			ivl.day = n
+		case "h":
+			ivl.hour = n
+		case "m":
+			ivl.minute = n
+		case "s":
+			ivl.second = n
+		}
+	}
+	return ivl, nil
+}
+
+var isoIntervalDateUnitRank = map[byte]int{
+	'Y': 0,
+	'M': 1,
+	'W': 2,
+	'D': 3,
+}
+
+var isoIntervalTimeUnitRank = map[byte]int{
+	'H': 4,
+	'M': 5,
+	'S': 6,
+}
+
+// ParseIntervalISO parses an ISO 8601 duration string and returns
+// an Interval, such as "P1Y2M3DT4H5M6S" or "P2W".
+// Valid units are "Y", "M", "W" and "D" before the "T" designator
+// and "H", "M" and "S" after it. Weeks are added to the day component.
+// Both a leading sign, negating the whole interval, and signs
+// on individual components, such as "P1M-1D", are accepted.
+// This implementation disallows:
+//   - fractions of a unit.
+//   - repeating a unit.
+//   - units out of order.
+func ParseIntervalISO(s string) (Interval, error) {
+	return parseIntervalISO(s, false)
+}
+
+// parseIntervalISO parses an ISO 8601 duration such as "P1Y2M3DT4H5M6S".
+// Fractional seconds are truncated if truncate is true
+// and rejected otherwise.
+func parseIntervalISO(s string, truncate bool) (Interval, error) {
+	// [-+]?P([-+]?[0-9]+[YMWD])*(T([-+]?[0-9]+[HMS])+)?
+	orig := s
+	var ivl Interval
+
+	// Consume [-+]?
+	negAll := false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		negAll = s[0] == '-'
+		s = s[1:]
+	}
+
+	if s == "" || s[0] != 'P' {
+		return ivl, errors.New("timeapi: invalid interval " + strconv.Quote(orig))
+	}
+	s = s[1:]
+	if s == "" {
+		return ivl, errors.New("timeapi: invalid interval " + strconv.Quote(orig))
+	}
+
+	maxRank := -1
+	inTime := false
+	weeks := 0
+	for s != "" {
+		if s[0] == 'T' {
+			if inTime || len(s) == 1 {
+				return ivl, errors.New("timeapi: invalid interval " + strconv.Quote(orig))
+			}
+			inTime = true
+			s = s[1:]
+			continue
+		}
+
+		// Consume [-+]?
+		neg := false
+		if c := s[0]; c == '-' || c == '+' {
+			neg = c == '-'
+			s = s[1:]
+		}
+
+		// The next character must be [0-9]
+		if s == "" || !('0' <= s[0] && s[0] <= '9') {
+			return ivl, errors.New("timeapi: invalid interval " + strconv.Quote(orig))
+		}
+		// Consume [0-9]*
+		v, rem, err := leadingInt(s)
+		if err != nil {
+			return ivl, errors.New("timeapi: invalid interval " + strconv.Quote(orig))
+		}
+		s = rem
+
+		// Consume (\.[0-9]*)?
+		frac := false
+		if s != "" && s[0] == '.' {
+			_, rem, _ := leadingInt(s[1:])
+			if len(rem) == len(s)-1 {
+				return ivl, errors.New("timeapi: invalid interval " + strconv.Quote(orig))
+			}
+			frac = true
+			s = rem
+		}
+
+		if s == "" {
+			return ivl, errors.New("timeapi: missing unit in interval " + strconv.Quote(orig))
+		}
+		u := s[0]
+		s = s[1:]
+
+		ranks := isoIntervalDateUnitRank
+		if inTime {
+			ranks = isoIntervalTimeUnitRank
+		}
+		rank, ok := ranks[u]
+		if !ok {
+			return ivl, errors.New("timeapi: unknown unit " + strconv.Quote(string(u)) + " in interval " + strconv.Quote(orig))
+		}
+		if rank == maxRank {
+			return ivl, errors.New("timeapi: unit " + strconv.Quote(string(u)) + " repeated in interval " + strconv.Quote(orig))
+		}
+		if rank < maxRank {
+			return ivl, errors.New("timeapi: unit " + strconv.Quote(string(u)) + " must be in the order of Y, M, W, D, T, H, M, S in interval " + strconv.Quote(orig))
+		}
+		maxRank = rank
+
+		if frac && (u != 'S' || !truncate) {
+			return ivl, errors.New("timeapi: fractional value in interval " + strconv.Quote(orig))
+		}
+
+		n, ok := signedInt(v, neg != negAll)
+		if !ok {
 			// overflow
 			return ivl, errors.New("timeapi: invalid interval " + strconv.Quote(orig))
 		}
+		switch rank {
+		case 0:
+			ivl.year = n
+		case 1:
+			ivl.month = n
+		case 2:
+			if n > math.MaxInt/7 || n < math.MinInt/7 {
+				// overflow
+				return ivl, errors.New("timeapi: invalid interval " + strconv.Quote(orig))
+			}
+			weeks = n
+			ivl.day = 7 * n
+		case 3:
+			if (weeks > 0 && n > math.MaxInt-7*weeks) || (weeks < 0 && n < math.MinInt-7*weeks) {
+				// overflow
+				return ivl, errors.New("timeapi: invalid interval " + strconv.Quote(orig))
+			}
+			ivl.day = 7*weeks + n
+		case 4:
+			ivl.hour = n
+		case 5:
+			ivl.minute = n
+		case 6:
+			ivl.second = n
+		}
+	}
+	return ivl, nil
+}
+
+var postgresIntervalUnits = map[string]string{
+	"year":  "y",
+	"years": "y",
+	"mon":   "mo",
+	"mons":  "mo",
+	"day":   "d",
+	"days":  "d",
+	"hour":  "h",
+	"hours": "h",
+	"min":   "m",
+	"mins":  "m",
+	"sec":   "s",
+	"secs":  "s",
+}
+
+// parsePostgresInterval parses an interval in the postgres or
+// postgres_verbose output style of Postgres, such as
+// "1 year 2 mons 3 days 04:05:06" or "@ 1 year 2 mons 3 days 4 hours ago".
+// Fractional seconds are truncated.
+func parsePostgresInterval(s string) (Interval, error) {
+	orig := s
+	var ivl Interval
+
+	fields := strings.Fields(s)
+	verbose := len(fields) > 0 && fields[0] == "@"
+	ago := false
+	if verbose {
+		fields = fields[1:]
+		if len(fields) > 0 && fields[len(fields)-1] == "ago" {
+			ago = true
+			fields = fields[:len(fields)-1]
+		}
+		// Special case: postgres_verbose writes the zero interval as "@ 0".
+		if len(fields) == 1 && fields[0] == "0" {
+			return ivl, nil
+		}
+	}
+	if len(fields) == 0 {
+		return ivl, errors.New("timeapi: invalid interval " + strconv.Quote(orig))
+	}
+
+	seen := map[string]bool{}
+	for len(fields) > 0 {
+		f := fields[0]
+
+		// [-+]?hh:mm:ss(.[0-9]*)?
+		if strings.Contains(f, ":") {
+			if seen["h"] || seen["m"] || seen["s"] {
+				return ivl, errors.New("timeapi: time repeated in interval " + strconv.Quote(orig))
+			}
+			hour, min, sec, err := parsePostgresIntervalTime(f)
+			if err != nil {
+				return ivl, errors.New("timeapi: invalid interval " + strconv.Quote(orig))
+			}
+			ivl.hour, ivl.minute, ivl.second = hour, min, sec
+			seen["h"], seen["m"], seen["s"] = true, true, true
+			fields = fields[1:]
+			continue
+		}
+
+		if len(fields) < 2 {
+			return ivl, errors.New("timeapi: missing unit in interval " + strconv.Quote(orig))
+		}
+		u, ok := postgresIntervalUnits[fields[1]]
+		if !ok {
+			return ivl, errors.New("timeapi: unknown unit " + strconv.Quote(fields[1]) + " in interval " + strconv.Quote(orig))
+		}
+		if seen[u] {
+			return ivl, errors.New("timeapi: unit " + strconv.Quote(fields[1]) + " repeated in interval " + strconv.Quote(orig))
+		}
+		seen[u] = true
+
+		if u == "s" {
+			f, _, _ = strings.Cut(f, ".")
+		}
+		v, err := strconv.Atoi(f)
+		if err != nil {
+			return ivl, errors.New("timeapi: invalid interval " + strconv.Quote(orig))
+		}
 
 		switch u {
 		case "y":
-			ivl.year = int(v)
+			ivl.year = v
 		case "mo":
-			ivl.month = int(v)
+			ivl.month = v
 		case "d":
-			ivl.day = int(v)
+			ivl.day = v
 		case "h":
-			ivl.hour = int(v)
+			ivl.hour = v
 		case "m":
-			ivl.minute = int(v)
+			ivl.minute = v
 		case "s":
-			ivl.second = int(v)
+			ivl.second = v
 		}
+		fields = fields[2:]
+	}
+
+	if ago {
+		ivl = Interval{-ivl.year, -ivl.month, -ivl.day, -ivl.hour, -ivl.minute, -ivl.second}
 	}
 	return ivl, nil
 }
 
+// parsePostgresIntervalTime parses the [-+]?hh:mm:ss(.[0-9]*)? time part
+// of an interval. The sign applies to all components.
+func parsePostgresIntervalTime(s string) (hour, min, sec int, err error) {
+	neg := false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return 0, 0, 0, errors.New("timeapi: invalid interval time " + strconv.Quote(s))
+	}
+	parts[2], _, _ = strings.Cut(parts[2], ".")
+
+	var v [3]int
+	for i, p := range parts {
+		n, rem, err := leadingInt(p)
+		if err != nil || p == "" || rem != "" || n > math.MaxInt {
+			return 0, 0, 0, errors.New("timeapi: invalid interval time " + strconv.Quote(s))
+		}
+		v[i] = int(n)
+		if neg {
+			v[i] = -v[i]
+		}
+	}
+	return v[0], v[1], v[2], nil
+}
+
+// isISODuration reports whether s looks like an ISO 8601 duration,
+// that is, it starts with an optionally signed "P" designator.
+func isISODuration(s string) bool {
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		s = s[1:]
+	}
+	return strings.HasPrefix(s, "P")
+}
+
+// signedInt returns v as an int, negated if neg is true,
+// and reports whether the result is in the int range.
+func signedInt(v uint64, neg bool) (int, bool) {
+	if neg {
+		if v > uint64(math.MaxInt)+1 {
+			return 0, false
+		}
+		return -int(v), true
+	}
+	if v > math.MaxInt {
+		return 0, false
+	}
+	return int(v), true
+}
+
 // leadingInt consumes the leading [0-9]* from s.
 func leadingInt[bytes []byte | string](s bytes) (x uint64, rem bytes, err error) {
 	i := 0
@@ -275,3 +719,534 @@ func abs(n int) int {
 	}
 	return n
 }
+
+// floorDiv returns a/b rounded toward negative infinity.
+func floorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+// floorMod returns a modulo b with the sign of b.
+func floorMod(a, b int) int {
+	return a - floorDiv(a, b)*b
+}
+
+// addInt returns a+b and reports whether the sum did not overflow.
+func addInt(a, b int) (int, bool) {
+	c := a + b
+	return c, (c > a) == (b > 0)
+}
+
+// mulInt returns a*b and reports whether the product did not overflow.
+func mulInt(a, b int) (int, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	c := a * b
+	if (a == -1 && b == math.MinInt) || (b == -1 && a == math.MinInt) || c/b != a {
+		return c, false
+	}
+	return c, true
+}
+
+// parseRange parses a Postgres range literal, such as "[2024-01-01,2024-02-01)",
+// and returns its bounds and whether they are inclusive. Quoted bounds are unquoted.
+// It reports empty for the "empty" literal and an empty string for infinite bounds.
+func parseRange(s string) (lower, upper string, lowerInc, upperInc, empty bool, err error) {
+	orig := s
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, "empty") {
+		return "", "", false, false, true, nil
+	}
+
+	if len(s) < 3 {
+		return "", "", false, false, false, errors.New("timeapi: invalid range " + strconv.Quote(orig))
+	}
+	switch s[0] {
+	case '[':
+		lowerInc = true
+	case '(':
+	default:
+		return "", "", false, false, false, errors.New("timeapi: invalid range " + strconv.Quote(orig))
+	}
+	switch s[len(s)-1] {
+	case ']':
+		upperInc = true
+	case ')':
+	default:
+		return "", "", false, false, false, errors.New("timeapi: invalid range " + strconv.Quote(orig))
+	}
+	s = s[1 : len(s)-1]
+
+	lower, s, ok := consumeRangeBound(s)
+	if !ok || s == "" || s[0] != ',' {
+		return "", "", false, false, false, errors.New("timeapi: invalid range " + strconv.Quote(orig))
+	}
+	upper, s, ok = consumeRangeBound(s[1:])
+	if !ok || s != "" {
+		return "", "", false, false, false, errors.New("timeapi: invalid range " + strconv.Quote(orig))
+	}
+	return lower, upper, lowerInc, upperInc, false, nil
+}
+
+// consumeRangeBound consumes a possibly quoted range bound from s.
+// Within quotes, a backslash or a doubled quote escapes the next character.
+func consumeRangeBound(s string) (bound, rem string, ok bool) {
+	if s == "" || s[0] != '"' {
+		i := strings.IndexByte(s, ',')
+		if i < 0 {
+			i = len(s)
+		}
+		return strings.TrimSpace(s[:i]), s[i:], true
+	}
+
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\' && i+1 < len(s):
+			i++
+			b.WriteByte(s[i])
+		case c == '"' && i+1 < len(s) && s[i+1] == '"':
+			i++
+			b.WriteByte('"')
+		case c == '"':
+			return b.String(), s[i+1:], true
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", s, false
+}
+
+// ParseTimeRange parses a time range string in the "15:04:05-15:04:05"
+// format, such as "09:00:00-17:00:00" or "22:00:00-02:00:00".
+func ParseTimeRange(s string) (TimeRange, error) {
+	start, end, ok := strings.Cut(s, "-")
+	if !ok {
+		return TimeRange{}, errors.New("timeapi: invalid time range " + strconv.Quote(s))
+	}
+	st, err := time.Parse(timeLayout, start)
+	if err != nil {
+		return TimeRange{}, errors.New("timeapi: invalid time range " + strconv.Quote(s))
+	}
+	et, err := time.Parse(timeLayout, end)
+	if err != nil {
+		return TimeRange{}, errors.New("timeapi: invalid time range " + strconv.Quote(s))
+	}
+	return NewTimeRange(
+		NewTime(st.Hour(), st.Minute(), st.Second()),
+		NewTime(et.Hour(), et.Minute(), et.Second()),
+	), nil
+}
+
+// parseMultirange parses a Postgres multirange literal, such as
+// "{[2024-01-01,2024-01-05),[2024-02-01,2024-02-03)}",
+// and returns the literals of its ranges.
+func parseMultirange(s string) ([]string, error) {
+	orig := s
+	s = strings.TrimSpace(s)
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return nil, errors.New("timeapi: invalid multirange " + strconv.Quote(orig))
+	}
+	s = strings.TrimSpace(s[1 : len(s)-1])
+
+	var ranges []string
+	for s != "" {
+		i := consumeRangeLiteral(s)
+		if i < 0 {
+			return nil, errors.New("timeapi: invalid multirange " + strconv.Quote(orig))
+		}
+		ranges = append(ranges, s[:i])
+
+		s = strings.TrimSpace(s[i:])
+		if s == "" {
+			break
+		}
+		if s[0] != ',' {
+			return nil, errors.New("timeapi: invalid multirange " + strconv.Quote(orig))
+		}
+		if s = strings.TrimSpace(s[1:]); s == "" {
+			return nil, errors.New("timeapi: invalid multirange " + strconv.Quote(orig))
+		}
+	}
+	return ranges, nil
+}
+
+// consumeRangeLiteral returns the length of the range literal
+// at the start of s, or -1 if s doesn't start with one.
+func consumeRangeLiteral(s string) int {
+	if len(s) >= 5 && strings.EqualFold(s[:5], "empty") {
+		return 5
+	}
+	if s == "" || (s[0] != '[' && s[0] != '(') {
+		return -1
+	}
+
+	quoted := false
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\':
+			i++
+		case c == '"':
+			quoted = !quoted
+		case !quoted && (c == ']' || c == ')'):
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// ParseRRule parses a recurrence rule in the RFC 5545 RRULE format,
+// such as "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH". An optional "RRULE:"
+// prefix is skipped and rule part names and values are case-insensitive.
+// Valid rule parts are "FREQ", "INTERVAL", "COUNT", "UNTIL", "BYMONTH",
+// "BYMONTHDAY", "BYDAY", "BYSETPOS" and "WKST". UNTIL must be a date and
+// time in UTC, such as "19971224T000000Z". A missing WKST means Monday.
+func ParseRRule(s string) (RRule, error) {
+	orig := s
+	s = strings.ToUpper(strings.TrimSpace(s))
+	s = strings.TrimPrefix(s, "RRULE:")
+
+	r := RRule{WeekStart: NewWeekday(time.Monday)}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(s, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return RRule{}, errors.New("timeapi: invalid rrule part " + strconv.Quote(part))
+		}
+		if seen[name] {
+			return RRule{}, errors.New("timeapi: rrule part " + strconv.Quote(name) + " repeated")
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			i := slices.Index(frequencyNames[:], value)
+			if i < int(FreqSecondly) {
+				err = errors.New("invalid frequency")
+			}
+			r.Freq = Frequency(i)
+		case "INTERVAL":
+			r.Interval, err = parsePositiveInt(value)
+		case "COUNT":
+			r.Count, err = parsePositiveInt(value)
+		case "UNTIL":
+			r.Until.t, err = time.Parse(rruleUntilLayout, value)
+		case "BYMONTH":
+			r.ByMonth, err = parseList(value, func(v string) (time.Month, error) {
+				m, err := strconv.Atoi(v)
+				return time.Month(m), err
+			})
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseList(value, strconv.Atoi)
+		case "BYDAY":
+			r.ByDay, err = parseList(value, parseRRuleDay)
+		case "BYSETPOS":
+			r.BySetPos, err = parseList(value, strconv.Atoi)
+		case "WKST":
+			r.WeekStart, err = parseRRuleWeekday(value)
+		default:
+			return RRule{}, errors.New("timeapi: rrule part " + strconv.Quote(name) + " not supported")
+		}
+		if err != nil {
+			return RRule{}, errors.New("timeapi: invalid rrule part " + strconv.Quote(part))
+		}
+	}
+	if !seen["FREQ"] {
+		return RRule{}, errors.New("timeapi: missing FREQ in rrule " + strconv.Quote(orig))
+	}
+	if err := r.validate(); err != nil {
+		return RRule{}, err
+	}
+	return r, nil
+}
+
+// parseRRuleDay parses a BYDAY entry, such as "MO", "2TU" or "-1FR".
+func parseRRuleDay(s string) (RRuleDay, error) {
+	if len(s) < 2 {
+		return RRuleDay{}, errors.New("invalid weekday")
+	}
+	wd, err := parseRRuleWeekday(s[len(s)-2:])
+	if err != nil {
+		return RRuleDay{}, err
+	}
+
+	var n int
+	if prefix := s[:len(s)-2]; prefix != "" {
+		if n, err = strconv.Atoi(prefix); err != nil || n == 0 {
+			return RRuleDay{}, errors.New("invalid ordinal")
+		}
+	}
+	return RRuleDay{N: n, Weekday: wd}, nil
+}
+
+// parseRRuleWeekday parses a two-letter weekday, such as "MO".
+func parseRRuleWeekday(s string) (Weekday, error) {
+	i := slices.Index(weekdayShortNames[:], s)
+	if i < 0 {
+		return Weekday{}, errors.New("invalid weekday")
+	}
+	return NewWeekday(time.Weekday(i)), nil
+}
+
+// parsePositiveInt parses a decimal integer greater than zero.
+func parsePositiveInt(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return 0, errors.New("invalid positive integer")
+	}
+	return n, nil
+}
+
+// parseList parses a comma-separated list of values with parse.
+func parseList[T any](s string, parse func(string) (T, error)) ([]T, error) {
+	parts := strings.Split(s, ",")
+	values := make([]T, len(parts))
+	for i, p := range parts {
+		var err error
+		if values[i], err = parse(p); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// cronMacros are the cron expressions of the supported macros.
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var cronMonthNames = map[string]int{
+	"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+	"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+}
+
+var cronWeekdayNames = map[string]int{
+	"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	"SUNDAY": 0, "MONDAY": 1, "TUESDAY": 2, "WEDNESDAY": 3, "THURSDAY": 4, "FRIDAY": 5, "SATURDAY": 6,
+}
+
+// ParseCron parses a cron expression with five fields, such as
+// "*/15 9-17 * * MON-FRI", or six fields, with the first one for the second.
+// Each field is a comma-separated list of "*", a value or a range "a-b",
+// optionally followed by a step "/n". A value with a step means the
+// range from the value to the end of the field.
+//
+// Months can be given as "JAN" to "DEC" and days of week as "SUN" to "SAT",
+// "SUNDAY" to "SATURDAY" or 0 to 7, where both 0 and 7 are Sunday. Names are
+// case-insensitive. The day of month and day of week fields accept "?" as "*".
+// The macros "@yearly", "@annually", "@monthly", "@weekly", "@daily",
+// "@midnight" and "@hourly" are also accepted.
+func ParseCron(s string) (Cron, error) {
+	// the expression is kept with single spaces between the fields,
+	// so it can be written back without escaping
+	fields := strings.Fields(s)
+	c := Cron{expr: strings.Join(fields, " ")}
+	if strings.HasPrefix(c.expr, "@") {
+		m, ok := cronMacros[strings.ToLower(c.expr)]
+		if !ok {
+			return Cron{}, errors.New("timeapi: unknown cron macro " + strconv.Quote(s))
+		}
+		fields = strings.Fields(m)
+	}
+
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return Cron{}, errors.New("timeapi: cron expression " + strconv.Quote(s) + " must have 5 or 6 fields")
+	}
+	for _, i := range []int{3, 5} {
+		if fields[i] == "?" {
+			fields[i] = "*"
+		}
+	}
+
+	var err error
+	if c.second, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return Cron{}, err
+	}
+	if c.minute, err = parseCronField(fields[1], 0, 59, nil); err != nil {
+		return Cron{}, err
+	}
+	if c.hour, err = parseCronField(fields[2], 0, 23, nil); err != nil {
+		return Cron{}, err
+	}
+	if c.dom, err = parseCronField(fields[3], 1, 31, nil); err != nil {
+		return Cron{}, err
+	}
+	if c.month, err = parseCronField(fields[4], 1, 12, cronMonthNames); err != nil {
+		return Cron{}, err
+	}
+	if c.dow, err = parseCronField(fields[5], 0, 7, cronWeekdayNames); err != nil {
+		return Cron{}, err
+	}
+	if c.dow&(1<<7) != 0 {
+		c.dow = c.dow&^(1<<7) | 1
+	}
+	c.domOrDow = !strings.HasPrefix(fields[3], "*") && !strings.HasPrefix(fields[5], "*")
+	return c, nil
+}
+
+// parseCronField parses a cron field with values from min to max
+// and returns the values as a bit set.
+func parseCronField(field string, min, max int, names map[string]int) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(item, "/")
+		lo, hi, step := min, max, 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepStr); err != nil || step <= 0 {
+				return 0, errors.New("timeapi: invalid cron field " + strconv.Quote(field))
+			}
+		}
+
+		var ok bool
+		switch a, b, isRange := strings.Cut(rng, "-"); {
+		case rng == "*":
+			ok = true
+		case isRange:
+			lo, ok = parseCronValue(a, min, max, names)
+			if ok {
+				hi, ok = parseCronValue(b, min, max, names)
+			}
+			ok = ok && lo <= hi
+		default:
+			lo, ok = parseCronValue(rng, min, max, names)
+			if !hasStep {
+				hi = lo
+			}
+		}
+		if !ok {
+			return 0, errors.New("timeapi: invalid cron field " + strconv.Quote(field))
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+// parseCronValue parses a number from min to max or one of the names.
+func parseCronValue(s string, min, max int, names map[string]int) (int, bool) {
+	if v, ok := names[strings.ToUpper(s)]; ok {
+		return v, true
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < min || v > max || s[0] == '+' || s[0] == '-' {
+		return 0, false
+	}
+	return v, true
+}
+
+// ParseWeekdaySet parses a comma-separated list of days and ranges of days,
+// such as "MO,WE-FR". Days are given as two-letter names, "MO" to "SU",
+// or full names, "MONDAY" to "SUNDAY". A range "a-b" includes both ends
+// and may wrap around the end of the week, so "FR-MO" is Friday to Monday.
+// An empty string is parsed as the empty set.
+func ParseWeekdaySet(s string) (WeekdaySet, error) {
+	var set WeekdaySet
+	if s == "" {
+		return set, nil
+	}
+
+	for _, item := range strings.Split(s, ",") {
+		first, last, isRange := strings.Cut(item, "-")
+		from, ok := parseWeekdayName(first)
+		if !ok {
+			return WeekdaySet{}, errors.New("timeapi: invalid weekday set " + strconv.Quote(s))
+		}
+		to := from
+		if isRange {
+			if to, ok = parseWeekdayName(last); !ok {
+				return WeekdaySet{}, errors.New("timeapi: invalid weekday set " + strconv.Quote(s))
+			}
+		}
+		for d := from; ; d = (d + 1) % 7 {
+			set.bits |= 1 << d
+			if d == to {
+				break
+			}
+		}
+	}
+	return set, nil
+}
+
+// parseWeekdayName parses a two-letter or a full weekday name.
+func parseWeekdayName(s string) (time.Weekday, bool) {
+	if i := slices.Index(weekdayShortNames[:], s); i >= 0 {
+		return time.Weekday(i), true
+	}
+	wd, ok := namesToWeekday[s]
+	return wd, ok
+}
+
+// ParseWeekday parses a weekday in the forms accepted by the policy p.
+// The full upper-case names, such as "MONDAY", are always accepted.
+// The error lists the values accepted by the policy.
+func ParseWeekday(s string, p WeekdayPolicy) (Weekday, error) {
+	name := s
+	if p&WeekdayCaseInsensitive != 0 {
+		name = strings.ToUpper(s)
+	}
+	if wd, ok := namesToWeekday[name]; ok {
+		return NewWeekday(wd), nil
+	}
+
+	if p&WeekdayAbbreviations != 0 {
+		for wd, full := range weekdayNames {
+			if name == full[:3] || name == weekdayShortNames[wd] {
+				return NewWeekday(wd), nil
+			}
+		}
+	}
+
+	if p&WeekdayISONumbers != 0 {
+		if n, err := strconv.Atoi(s); err == nil && s[0] != '+' && s[0] != '-' {
+			if wd, err := WeekdayFromISONumber(n); err == nil {
+				return wd, nil
+			}
+		}
+	}
+
+	return Weekday{}, errors.New("timeapi: invalid weekday " + strconv.Quote(s) + ", allowed values are " + weekdayAllowedValues(p))
+}
+
+// weekdayAllowedValues describes the values accepted by ParseWeekday
+// with the policy p.
+func weekdayAllowedValues(p WeekdayPolicy) string {
+	var values []string
+	for _, wd := range weekdaysFrom(time.Monday) {
+		values = append(values, weekdayNames[wd])
+	}
+	if p&WeekdayAbbreviations != 0 {
+		for _, wd := range weekdaysFrom(time.Monday) {
+			values = append(values, weekdayNames[wd][:3])
+		}
+		for _, wd := range weekdaysFrom(time.Monday) {
+			values = append(values, weekdayShortNames[wd])
+		}
+	}
+
+	s := strings.Join(values, ", ")
+	if p&WeekdayCaseInsensitive != 0 {
+		s += " in any case"
+	}
+	if p&WeekdayISONumbers != 0 {
+		s += " and 1 (MONDAY) to 7 (SUNDAY)"
+	}
+	return s
+}
@@ -0,0 +1,346 @@
+package timeapi
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NameWidth selects the width of a localized name.
+type NameWidth uint8
+
+const (
+	// NameFull is the full name, such as "Monday" or "January".
+	NameFull NameWidth = iota
+
+	// NameShort is the abbreviated name, such as "Mon" or "Jan".
+	NameShort
+
+	// NameNarrow is the narrowest name, usually a single letter,
+	// such as "M" or "J". Narrow names are often ambiguous.
+	NameNarrow
+)
+
+// LocaleNames are the localized weekday and month names of a locale,
+// in the stand-alone form, that is, as used on their own and not
+// within a date. Both arrays are indexed by NameWidth first.
+type LocaleNames struct {
+	// Weekdays are the weekday names, indexed by time.Weekday.
+	Weekdays [3][7]string
+	// Months are the month names, indexed by time.Month-1.
+	Months [3][12]string
+}
+
+var (
+	localesMu sync.RWMutex
+	locales   = map[string]LocaleNames{
+		"en": {
+			Weekdays: [3][7]string{
+				{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+				{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+				{"S", "M", "T", "W", "T", "F", "S"},
+			},
+			Months: [3][12]string{
+				{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+				{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+				{"J", "F", "M", "A", "M", "J", "J", "A", "S", "O", "N", "D"},
+			},
+		},
+		"de": {
+			Weekdays: [3][7]string{
+				{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+				{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+				{"S", "M", "D", "M", "D", "F", "S"},
+			},
+			Months: [3][12]string{
+				{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+				{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+				{"J", "F", "M", "A", "M", "J", "J", "A", "S", "O", "N", "D"},
+			},
+		},
+		"fr": {
+			Weekdays: [3][7]string{
+				{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+				{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+				{"D", "L", "M", "M", "J", "V", "S"},
+			},
+			Months: [3][12]string{
+				{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+				{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+				{"J", "F", "M", "A", "M", "J", "J", "A", "S", "O", "N", "D"},
+			},
+		},
+		"es": {
+			Weekdays: [3][7]string{
+				{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+				{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+				{"D", "L", "M", "X", "J", "V", "S"},
+			},
+			Months: [3][12]string{
+				{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+				{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+				{"E", "F", "M", "A", "M", "J", "J", "A", "S", "O", "N", "D"},
+			},
+		},
+		"it": {
+			Weekdays: [3][7]string{
+				{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+				{"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+				{"D", "L", "M", "M", "G", "V", "S"},
+			},
+			Months: [3][12]string{
+				{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+				{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+				{"G", "F", "M", "A", "M", "G", "L", "A", "S", "O", "N", "D"},
+			},
+		},
+		"pt": {
+			Weekdays: [3][7]string{
+				{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+				{"dom.", "seg.", "ter.", "qua.", "qui.", "sex.", "sáb."},
+				{"D", "S", "T", "Q", "Q", "S", "S"},
+			},
+			Months: [3][12]string{
+				{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+				{"jan.", "fev.", "mar.", "abr.", "mai.", "jun.", "jul.", "ago.", "set.", "out.", "nov.", "dez."},
+				{"J", "F", "M", "A", "M", "J", "J", "A", "S", "O", "N", "D"},
+			},
+		},
+		"nl": {
+			Weekdays: [3][7]string{
+				{"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+				{"zo", "ma", "di", "wo", "do", "vr", "za"},
+				{"Z", "M", "D", "W", "D", "V", "Z"},
+			},
+			Months: [3][12]string{
+				{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+				{"jan", "feb", "mrt", "apr", "mei", "jun", "jul", "aug", "sep", "okt", "nov", "dec"},
+				{"J", "F", "M", "A", "M", "J", "J", "A", "S", "O", "N", "D"},
+			},
+		},
+		"pl": {
+			Weekdays: [3][7]string{
+				{"niedziela", "poniedziałek", "wtorek", "środa", "czwartek", "piątek", "sobota"},
+				{"niedz.", "pon.", "wt.", "śr.", "czw.", "pt.", "sob."},
+				{"N", "P", "W", "Ś", "C", "P", "S"},
+			},
+			Months: [3][12]string{
+				{"styczeń", "luty", "marzec", "kwiecień", "maj", "czerwiec", "lipiec", "sierpień", "wrzesień", "październik", "listopad", "grudzień"},
+				{"sty", "lut", "mar", "kwi", "maj", "cze", "lip", "sie", "wrz", "paź", "lis", "gru"},
+				{"S", "L", "M", "K", "M", "C", "L", "S", "W", "P", "L", "G"},
+			},
+		},
+		"sv": {
+			Weekdays: [3][7]string{
+				{"söndag", "måndag", "tisdag", "onsdag", "torsdag", "fredag", "lördag"},
+				{"sön", "mån", "tis", "ons", "tors", "fre", "lör"},
+				{"S", "M", "T", "O", "T", "F", "L"},
+			},
+			Months: [3][12]string{
+				{"januari", "februari", "mars", "april", "maj", "juni", "juli", "augusti", "september", "oktober", "november", "december"},
+				{"jan.", "feb.", "mars", "apr.", "maj", "juni", "juli", "aug.", "sep.", "okt.", "nov.", "dec."},
+				{"J", "F", "M", "A", "M", "J", "J", "A", "S", "O", "N", "D"},
+			},
+		},
+		"da": {
+			Weekdays: [3][7]string{
+				{"søndag", "mandag", "tirsdag", "onsdag", "torsdag", "fredag", "lørdag"},
+				{"søn.", "man.", "tirs.", "ons.", "tors.", "fre.", "lør."},
+				{"S", "M", "T", "O", "T", "F", "L"},
+			},
+			Months: [3][12]string{
+				{"januar", "februar", "marts", "april", "maj", "juni", "juli", "august", "september", "oktober", "november", "december"},
+				{"jan.", "feb.", "mar.", "apr.", "maj", "jun.", "jul.", "aug.", "sep.", "okt.", "nov.", "dec."},
+				{"J", "F", "M", "A", "M", "J", "J", "A", "S", "O", "N", "D"},
+			},
+		},
+		"nb": {
+			Weekdays: [3][7]string{
+				{"søndag", "mandag", "tirsdag", "onsdag", "torsdag", "fredag", "lørdag"},
+				{"søn.", "man.", "tir.", "ons.", "tor.", "fre.", "lør."},
+				{"S", "M", "T", "O", "T", "F", "L"},
+			},
+			Months: [3][12]string{
+				{"januar", "februar", "mars", "april", "mai", "juni", "juli", "august", "september", "oktober", "november", "desember"},
+				{"jan", "feb", "mar", "apr", "mai", "jun", "jul", "aug", "sep", "okt", "nov", "des"},
+				{"J", "F", "M", "A", "M", "J", "J", "A", "S", "O", "N", "D"},
+			},
+		},
+		"fi": {
+			Weekdays: [3][7]string{
+				{"sunnuntai", "maanantai", "tiistai", "keskiviikko", "torstai", "perjantai", "lauantai"},
+				{"su", "ma", "ti", "ke", "to", "pe", "la"},
+				{"S", "M", "T", "K", "T", "P", "L"},
+			},
+			Months: [3][12]string{
+				{"tammikuu", "helmikuu", "maaliskuu", "huhtikuu", "toukokuu", "kesäkuu", "heinäkuu", "elokuu", "syyskuu", "lokakuu", "marraskuu", "joulukuu"},
+				{"tammi", "helmi", "maalis", "huhti", "touko", "kesä", "heinä", "elo", "syys", "loka", "marras", "joulu"},
+				{"T", "H", "M", "H", "T", "K", "H", "E", "S", "L", "M", "J"},
+			},
+		},
+		"ru": {
+			Weekdays: [3][7]string{
+				{"воскресенье", "понедельник", "вторник", "среда", "четверг", "пятница", "суббота"},
+				{"вс", "пн", "вт", "ср", "чт", "пт", "сб"},
+				{"В", "П", "В", "С", "Ч", "П", "С"},
+			},
+			Months: [3][12]string{
+				{"январь", "февраль", "март", "апрель", "май", "июнь", "июль", "август", "сентябрь", "октябрь", "ноябрь", "декабрь"},
+				{"янв.", "февр.", "март", "апр.", "май", "июнь", "июль", "авг.", "сент.", "окт.", "нояб.", "дек."},
+				{"Я", "Ф", "М", "А", "М", "И", "И", "А", "С", "О", "Н", "Д"},
+			},
+		},
+		"ja": {
+			Weekdays: [3][7]string{
+				{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+				{"日", "月", "火", "水", "木", "金", "土"},
+				{"日", "月", "火", "水", "木", "金", "土"},
+			},
+			Months: [3][12]string{
+				{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+				{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+				{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12"},
+			},
+		},
+		"zh": {
+			Weekdays: [3][7]string{
+				{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+				{"周日", "周一", "周二", "周三", "周四", "周五", "周六"},
+				{"日", "一", "二", "三", "四", "五", "六"},
+			},
+			Months: [3][12]string{
+				{"一月", "二月", "三月", "四月", "五月", "六月", "七月", "八月", "九月", "十月", "十一月", "十二月"},
+				{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+				{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12"},
+			},
+		},
+	}
+)
+
+// RegisterLocale adds the names of a locale, such as "pt-PT", or replaces
+// the names of an already known one. It returns an error if any name is empty.
+func RegisterLocale(locale string, names LocaleNames) error {
+	tag := normalizeLocale(locale)
+	if tag == "" {
+		return errors.New("timeapi: invalid locale " + strconv.Quote(locale))
+	}
+	for _, width := range names.Weekdays {
+		for _, name := range width {
+			if name == "" {
+				return fmt.Errorf("timeapi: locale %q has an empty weekday name", locale)
+			}
+		}
+	}
+	for _, width := range names.Months {
+		for _, name := range width {
+			if name == "" {
+				return fmt.Errorf("timeapi: locale %q has an empty month name", locale)
+			}
+		}
+	}
+
+	localesMu.Lock()
+	defer localesMu.Unlock()
+	locales[tag] = names
+	return nil
+}
+
+// lookupLocale returns the names of the locale. If the locale isn't known,
+// it falls back to its parent locales, so "de-AT" falls back to "de".
+func lookupLocale(locale string) (LocaleNames, error) {
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+
+	for tag := normalizeLocale(locale); tag != ""; {
+		if names, ok := locales[tag]; ok {
+			return names, nil
+		}
+		i := strings.LastIndexByte(tag, '-')
+		if i < 0 {
+			break
+		}
+		tag = tag[:i]
+	}
+	return LocaleNames{}, errors.New("timeapi: unknown locale " + strconv.Quote(locale))
+}
+
+// normalizeLocale returns the locale in lower case with "-" as the separator,
+// so "pt_BR" and "pt-br" are the same locale.
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+// LocalizedName returns the name of the weekday in the locale,
+// such as "Montag" for Monday in "de". Unknown regional locales fall
+// back to their language, so "de-AT" uses "de".
+func (w Weekday) LocalizedName(locale string, width NameWidth) (string, error) {
+	if width > NameNarrow {
+		return "", fmt.Errorf("timeapi: name width %d is invalid", width)
+	}
+	names, err := lookupLocale(locale)
+	if err != nil {
+		return "", err
+	}
+	return names.Weekdays[width][w.w], nil
+}
+
+// LocalizedMonthName returns the name of the month in the locale,
+// such as "März" for March in "de". Unknown regional locales fall
+// back to their language, so "de-AT" uses "de".
+func LocalizedMonthName(m time.Month, locale string, width NameWidth) (string, error) {
+	if m < time.January || m > time.December {
+		return "", fmt.Errorf("timeapi: month %d is out of range", m)
+	}
+	if width > NameNarrow {
+		return "", fmt.Errorf("timeapi: name width %d is invalid", width)
+	}
+	names, err := lookupLocale(locale)
+	if err != nil {
+		return "", err
+	}
+	return names.Months[width][m-1], nil
+}
+
+// ParseLocalizedWeekday parses the full or short name of a weekday
+// in the locale, such as "lundi" or "lun." in "fr". The match is
+// case-insensitive and the trailing dot of a short name is optional.
+// A narrow name is accepted only if no other weekday has the same one.
+func ParseLocalizedWeekday(s, locale string) (Weekday, error) {
+	names, err := lookupLocale(locale)
+	if err != nil {
+		return Weekday{}, err
+	}
+
+	match := func(name string) bool {
+		return strings.EqualFold(s, name) ||
+			strings.EqualFold(strings.TrimSuffix(s, "."), strings.TrimSuffix(name, "."))
+	}
+	for _, width := range [...]NameWidth{NameFull, NameShort} {
+		for wd, name := range names.Weekdays[width] {
+			if match(name) {
+				return NewWeekday(time.Weekday(wd)), nil
+			}
+		}
+	}
+
+	found := -1
+	for wd, name := range names.Weekdays[NameNarrow] {
+		if !match(name) {
+			continue
+		}
+		if found >= 0 {
+			// ambiguous
+			found = -1
+			break
+		}
+		found = wd
+	}
+	if found >= 0 {
+		return NewWeekday(time.Weekday(found)), nil
+	}
+
+	return Weekday{}, fmt.Errorf("timeapi: invalid weekday %q in locale %q", s, locale)
+}
@@ -0,0 +1,165 @@
+package timeapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/krhubert/assert"
+)
+
+func TestLocale(t *testing.T) {
+	t.Run("Table", func(t *testing.T) {
+		for tag, names := range locales {
+			for _, width := range names.Weekdays {
+				for _, name := range width {
+					assert.NotZero(t, name)
+				}
+			}
+			for _, width := range names.Months {
+				for _, name := range width {
+					assert.NotZero(t, name)
+				}
+			}
+			assert.Equal(t, normalizeLocale(tag), tag)
+		}
+		assert.True(t, len(locales) >= 12)
+	})
+
+	t.Run("LocalizedName", func(t *testing.T) {
+		tests := []struct {
+			w      time.Weekday
+			locale string
+			width  NameWidth
+			want   string
+		}{
+			{time.Monday, "en", NameFull, "Monday"},
+			{time.Monday, "en", NameShort, "Mon"},
+			{time.Monday, "en", NameNarrow, "M"},
+			{time.Monday, "de", NameFull, "Montag"},
+			{time.Sunday, "fr", NameShort, "dim."},
+			{time.Wednesday, "es", NameNarrow, "X"},
+			{time.Friday, "pl", NameFull, "piątek"},
+			{time.Saturday, "ru", NameShort, "сб"},
+			{time.Tuesday, "ja", NameFull, "火曜日"},
+			{time.Sunday, "zh", NameShort, "周日"},
+			{time.Monday, "de-AT", NameFull, "Montag"},
+			{time.Monday, "pt_BR", NameFull, "segunda-feira"},
+			{time.Monday, "EN-us", NameShort, "Mon"},
+		}
+		for _, tt := range tests {
+			name, err := NewWeekday(tt.w).LocalizedName(tt.locale, tt.width)
+			assert.NoError(t, err)
+			assert.Equal(t, name, tt.want)
+		}
+
+		_, err := NewWeekday(time.Monday).LocalizedName("xx", NameFull)
+		assert.ErrorContains(t, err, `unknown locale "xx"`)
+		_, err = NewWeekday(time.Monday).LocalizedName("", NameFull)
+		assert.ErrorContains(t, err, "unknown locale")
+		_, err = NewWeekday(time.Monday).LocalizedName("en", NameNarrow+1)
+		assert.ErrorContains(t, err, "name width 3 is invalid")
+	})
+
+	t.Run("LocalizedMonthName", func(t *testing.T) {
+		tests := []struct {
+			m      time.Month
+			locale string
+			width  NameWidth
+			want   string
+		}{
+			{time.January, "en", NameFull, "January"},
+			{time.December, "en", NameShort, "Dec"},
+			{time.March, "de", NameFull, "März"},
+			{time.February, "fr", NameShort, "févr."},
+			{time.July, "it", NameNarrow, "L"},
+			{time.May, "ru", NameFull, "май"},
+			{time.October, "ja", NameFull, "10月"},
+			{time.November, "zh", NameFull, "十一月"},
+		}
+		for _, tt := range tests {
+			name, err := LocalizedMonthName(tt.m, tt.locale, tt.width)
+			assert.NoError(t, err)
+			assert.Equal(t, name, tt.want)
+		}
+
+		_, err := LocalizedMonthName(13, "en", NameFull)
+		assert.ErrorContains(t, err, "month 13 is out of range")
+		_, err = LocalizedMonthName(time.January, "en", NameNarrow+1)
+		assert.ErrorContains(t, err, "is invalid")
+		_, err = LocalizedMonthName(time.January, "xx-YY", NameFull)
+		assert.ErrorContains(t, err, `unknown locale "xx-YY"`)
+	})
+
+	t.Run("ParseLocalizedWeekday", func(t *testing.T) {
+		tests := []struct {
+			s      string
+			locale string
+			want   time.Weekday
+		}{
+			{"Monday", "en", time.Monday},
+			{"monday", "en-GB", time.Monday},
+			{"THU", "en", time.Thursday},
+			{"W", "en", time.Wednesday},
+			{"lundi", "fr", time.Monday},
+			{"Lun.", "fr", time.Monday},
+			{"lun", "fr", time.Monday},
+			{"MIÉRCOLES", "es", time.Wednesday},
+			{"x", "es", time.Wednesday},
+			{"Środa", "pl", time.Wednesday},
+			{"пятница", "ru", time.Friday},
+			{"木", "ja", time.Thursday},
+			{"星期六", "zh", time.Saturday},
+		}
+		for _, tt := range tests {
+			wd, err := ParseLocalizedWeekday(tt.s, tt.locale)
+			assert.NoError(t, err)
+			assert.Equal(t, wd, NewWeekday(tt.want))
+		}
+
+		for _, s := range []string{"", "T", "S", "Montag", "Mo."} {
+			_, err := ParseLocalizedWeekday(s, "en")
+			assert.ErrorContains(t, err, "invalid weekday")
+		}
+		_, err := ParseLocalizedWeekday("Monday", "xx")
+		assert.ErrorContains(t, err, "unknown locale")
+	})
+
+	t.Run("RegisterLocale", func(t *testing.T) {
+		names, err := lookupLocale("en")
+		assert.NoError(t, err)
+		names.Weekdays[NameFull][time.Monday] = "Mondee"
+		names.Months[NameShort][time.January-1] = "Jany"
+
+		err = RegisterLocale("en_AU", names)
+		assert.NoError(t, err)
+		defer func() {
+			localesMu.Lock()
+			delete(locales, "en-au")
+			localesMu.Unlock()
+		}()
+
+		name, err := NewWeekday(time.Monday).LocalizedName("en-AU", NameFull)
+		assert.NoError(t, err)
+		assert.Equal(t, name, "Mondee")
+		name, err = LocalizedMonthName(time.January, "en-au", NameShort)
+		assert.NoError(t, err)
+		assert.Equal(t, name, "Jany")
+		wd, err := ParseLocalizedWeekday("mondee", "en-AU")
+		assert.NoError(t, err)
+		assert.Equal(t, wd, NewWeekday(time.Monday))
+
+		// the parent locale is unchanged
+		name, err = NewWeekday(time.Monday).LocalizedName("en", NameFull)
+		assert.NoError(t, err)
+		assert.Equal(t, name, "Monday")
+
+		names.Months[NameNarrow][5] = ""
+		err = RegisterLocale("xx", names)
+		assert.ErrorContains(t, err, "empty month name")
+		names.Weekdays[NameShort][0] = ""
+		err = RegisterLocale("xx", names)
+		assert.ErrorContains(t, err, "empty weekday name")
+		err = RegisterLocale(" ", LocaleNames{})
+		assert.ErrorContains(t, err, "invalid locale")
+	})
+}
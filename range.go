@@ -0,0 +1,665 @@
+package timeapi
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"time"
+)
+
+// DateRange represents a range of dates.
+//
+// A range is stored in the half-open form [start, end), the canonical
+// form of the Postgres daterange type, so a closed range [start, last]
+// is the same as [start, last+1). A range with start equal to end is empty.
+type DateRange struct {
+	start Date
+	end   Date
+}
+
+// NewDateRange returns a new half-open DateRange [start, end).
+// It panics if end is before start.
+func NewDateRange(start, end Date) DateRange {
+	if end.Before(start) {
+		panic(fmt.Sprintf("date range end %s is before start %s", end, start))
+	}
+	return DateRange{start: start, end: end}
+}
+
+// NewClosedDateRange returns a new closed DateRange [start, last].
+// It panics if last is before start.
+func NewClosedDateRange(start, last Date) DateRange {
+	if last.Before(start) {
+		panic(fmt.Sprintf("date range last %s is before start %s", last, start))
+	}
+	return DateRange{start: start, end: last.AddDays(1)}
+}
+
+func (r DateRange) String() string {
+	if r.IsEmpty() {
+		return "empty"
+	}
+	return "[" + r.start.String() + "," + r.end.String() + ")"
+}
+
+// Start returns the first date of the range.
+func (r DateRange) Start() Date {
+	return r.start
+}
+
+// End returns the first date after the range.
+func (r DateRange) End() Date {
+	return r.end
+}
+
+// Last returns the last date of the range.
+// For an empty range, Last is the day before Start.
+func (r DateRange) Last() Date {
+	return r.end.AddDays(-1)
+}
+
+// IsEmpty reports whether r contains no dates.
+func (r DateRange) IsEmpty() bool {
+	return !r.start.Before(r.end)
+}
+
+// Equal reports whether r and u contain the same dates.
+func (r DateRange) Equal(u DateRange) bool {
+	if r.IsEmpty() || u.IsEmpty() {
+		return r.IsEmpty() && u.IsEmpty()
+	}
+	return r.start.Equal(u.start) && r.end.Equal(u.end)
+}
+
+// Days returns the number of dates in the range.
+func (r DateRange) Days() int {
+	if r.IsEmpty() {
+		return 0
+	}
+	return r.end.Sub(r.start)
+}
+
+// Contains reports whether the date d is in the range.
+func (r DateRange) Contains(d Date) bool {
+	return !d.Before(r.start) && d.Before(r.end)
+}
+
+// Overlaps reports whether r and u have at least one date in common.
+func (r DateRange) Overlaps(u DateRange) bool {
+	return !r.IsEmpty() && !u.IsEmpty() &&
+		r.start.Before(u.end) && u.start.Before(r.end)
+}
+
+// Intersect returns the dates both in r and u.
+// It reports false if the ranges don't overlap.
+func (r DateRange) Intersect(u DateRange) (DateRange, bool) {
+	if !r.Overlaps(u) {
+		return DateRange{}, false
+	}
+	return DateRange{start: maxDate(r.start, u.start), end: minDate(r.end, u.end)}, true
+}
+
+// Union returns the dates in r or u. It reports false if the result
+// is not a single range, that is, if r and u neither overlap nor are adjacent.
+func (r DateRange) Union(u DateRange) (DateRange, bool) {
+	switch {
+	case r.IsEmpty():
+		return u, true
+	case u.IsEmpty():
+		return r, true
+	case r.end.Before(u.start) || u.end.Before(r.start):
+		return DateRange{}, false
+	}
+	return DateRange{start: minDate(r.start, u.start), end: maxDate(r.end, u.end)}, true
+}
+
+// Gap returns the dates between r and u. It reports false
+// if there are no dates between them, that is, if r and u
+// overlap, are adjacent or one of them is empty.
+func (r DateRange) Gap(u DateRange) (DateRange, bool) {
+	switch {
+	case r.IsEmpty() || u.IsEmpty():
+		return DateRange{}, false
+	case r.end.Before(u.start):
+		return DateRange{start: r.end, end: u.start}, true
+	case u.end.Before(r.start):
+		return DateRange{start: u.end, end: r.start}, true
+	}
+	return DateRange{}, false
+}
+
+// All returns an iterator over the dates of the range in ascending order.
+func (r DateRange) All() iter.Seq[Date] {
+	return func(yield func(Date) bool) {
+		for d := r.start; d.Before(r.end); d = d.AddDays(1) {
+			if !yield(d) {
+				return
+			}
+		}
+	}
+}
+
+type dateRangeJSON struct {
+	Start *Date `json:"start"`
+	End   *Date `json:"end"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The range is written as {"start":"2024-01-01","end":"2024-02-01"},
+// where end is the first date after the range. An empty range
+// is written as "empty", like in Value.
+func (r DateRange) MarshalJSON() ([]byte, error) {
+	if r.IsEmpty() {
+		return []byte(`"empty"`), nil
+	}
+	return json.Marshal(dateRangeJSON{Start: &r.start, End: &r.end})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It accepts the forms written by MarshalJSON.
+func (r *DateRange) UnmarshalJSON(b []byte) error {
+	if string(b) == `"empty"` {
+		*r = DateRange{}
+		return nil
+	}
+
+	var v dateRangeJSON
+	if err := json.Unmarshal(b, &v); err != nil {
+		if errors.As(err, &ErrJsonValue{}) {
+			return err
+		}
+		return NewErrJsonValue(err)
+	}
+	if v.Start == nil || v.End == nil {
+		return NewErrJsonValue(fmt.Errorf("date range %s is invalid", string(b)))
+	}
+	if v.End.Before(*v.Start) {
+		return NewErrJsonValue(fmt.Errorf("date range end %s is before start %s", v.End, v.Start))
+	}
+	r.start, r.end = *v.Start, *v.End
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+// The range is written in the Postgres daterange
+// format, such as "[2024-01-01,2024-02-01)".
+func (r DateRange) Value() (driver.Value, error) {
+	return r.String(), nil
+}
+
+// Scan implements the sql.Scanner interface.
+// It accepts []byte and string values in the Postgres daterange
+// format with any bounds, such as "[2024-01-01,2024-01-31]".
+// Ranges with infinite bounds are rejected.
+// Use sql.Null[DateRange] for nullable columns.
+func (r *DateRange) Scan(src any) error {
+	switch v := src.(type) {
+	case []byte:
+		return r.Scan(string(v))
+	case string:
+		dr, err := parseDateRange(v)
+		if err != nil {
+			return NewErrSqlValue(err)
+		}
+		*r = dr
+		return nil
+	case nil:
+		return NewErrSqlValue(fmt.Errorf("date range cannot be null"))
+	default:
+		return NewErrSqlValue(fmt.Errorf("date range cannot be scanned from %T", src))
+	}
+}
+
+// parseDateRange parses a Postgres daterange literal.
+func parseDateRange(s string) (DateRange, error) {
+	lower, upper, lowerInc, upperInc, empty, err := parseRange(s)
+	if err != nil {
+		return DateRange{}, err
+	}
+	if empty {
+		return DateRange{}, nil
+	}
+	if lower == "" || upper == "" {
+		return DateRange{}, fmt.Errorf("timeapi: date range %q is unbounded", s)
+	}
+
+	lt, err := time.Parse(dateLayout, lower)
+	if err != nil {
+		return DateRange{}, err
+	}
+	ut, err := time.Parse(dateLayout, upper)
+	if err != nil {
+		return DateRange{}, err
+	}
+
+	if ut.Before(lt) {
+		return DateRange{}, fmt.Errorf("timeapi: date range %q is invalid", s)
+	}
+
+	start := NewDate(lt.Date())
+	end := NewDate(ut.Date())
+	if !lowerInc {
+		start = start.AddDays(1)
+	}
+	if upperInc {
+		end = end.AddDays(1)
+	}
+	if !start.Before(end) {
+		return DateRange{}, nil
+	}
+	return DateRange{start: start, end: end}, nil
+}
+
+// minDate returns the earlier of the dates a and b.
+func minDate(a, b Date) Date {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
+
+// maxDate returns the later of the dates a and b.
+func maxDate(a, b Date) Date {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+// TimeRange represents a window of the day between two times,
+// such as opening hours. The start is inclusive and the end exclusive.
+//
+// A range whose end is before its start crosses midnight, so 22:00:00-02:00:00
+// covers the last two hours of a day and the first two of the next one.
+// A range with start equal to end covers the whole day.
+type TimeRange struct {
+	start Time
+	end   Time
+}
+
+// NewTimeRange returns a new TimeRange from start up to end.
+func NewTimeRange(start, end Time) TimeRange {
+	return TimeRange{start: start, end: end}
+}
+
+func (r TimeRange) String() string {
+	return r.start.String() + "-" + r.end.String()
+}
+
+// Start returns the first time of the range.
+func (r TimeRange) Start() Time {
+	return r.start
+}
+
+// End returns the first time after the range.
+func (r TimeRange) End() Time {
+	return r.end
+}
+
+// CrossesMidnight reports whether the range ends on the next day.
+func (r TimeRange) CrossesMidnight() bool {
+	return r.end.Before(r.start)
+}
+
+// IsFullDay reports whether the range covers the whole day.
+func (r TimeRange) IsFullDay() bool {
+	return r.start.Equal(r.end)
+}
+
+// Duration returns the length of the range. It is 24h for a full day range.
+func (r TimeRange) Duration() Duration {
+	return durationFromSeconds(r.end.seconds() - r.start.seconds() + r.wrap())
+}
+
+// Contains reports whether the time t is in the range.
+func (r TimeRange) Contains(t Time) bool {
+	secs := floorMod(t.seconds()-r.start.seconds(), 24*3600)
+	return secs < r.end.seconds()-r.start.seconds()+r.wrap()
+}
+
+// Overlaps reports whether r and u have at least one time in common.
+func (r TimeRange) Overlaps(u TimeRange) bool {
+	return r.Contains(u.start) || u.Contains(r.start)
+}
+
+// wrap returns the number of seconds to add to the end
+// of the range, so it is after the start.
+func (r TimeRange) wrap() int {
+	if r.start.Before(r.end) {
+		return 0
+	}
+	return 24 * 3600
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The range is written as "22:00:00-02:00:00".
+func (r TimeRange) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + r.String() + `"`), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It accepts the form parsed by ParseTimeRange.
+func (r *TimeRange) UnmarshalJSON(b []byte) error {
+	// strip quotes
+	if len(b) < 2 {
+		return NewErrJsonValue(fmt.Errorf("time range %q is invalid", string(b)))
+	}
+	b = b[1 : len(b)-1]
+
+	tr, err := ParseTimeRange(string(b))
+	if err != nil {
+		return NewErrJsonValue(err)
+	}
+	*r = tr
+	return nil
+}
+
+// Bounds describes whether the lower and upper bounds
+// of a DateTimeRange are inclusive or exclusive.
+type Bounds uint8
+
+const (
+	// BoundsClosedOpen includes the lower bound and excludes the upper one, "[)".
+	BoundsClosedOpen Bounds = iota
+	// BoundsClosed includes both bounds, "[]".
+	BoundsClosed
+	// BoundsOpen excludes both bounds, "()".
+	BoundsOpen
+	// BoundsOpenClosed excludes the lower bound and includes the upper one, "(]".
+	BoundsOpenClosed
+)
+
+var boundsNames = [...]string{
+	BoundsClosedOpen: "[)",
+	BoundsClosed:     "[]",
+	BoundsOpen:       "()",
+	BoundsOpenClosed: "(]",
+}
+
+func (b Bounds) String() string {
+	if int(b) < len(boundsNames) {
+		return boundsNames[b]
+	}
+	return fmt.Sprintf("Bounds(%d)", uint8(b))
+}
+
+// newBounds returns the Bounds with the given inclusive bounds.
+func newBounds(lowerInc, upperInc bool) Bounds {
+	switch {
+	case lowerInc && upperInc:
+		return BoundsClosed
+	case lowerInc:
+		return BoundsClosedOpen
+	case upperInc:
+		return BoundsOpenClosed
+	default:
+		return BoundsOpen
+	}
+}
+
+// lowerInc reports whether the lower bound is inclusive.
+func (b Bounds) lowerInc() bool {
+	return b == BoundsClosedOpen || b == BoundsClosed
+}
+
+// upperInc reports whether the upper bound is inclusive.
+func (b Bounds) upperInc() bool {
+	return b == BoundsClosed || b == BoundsOpenClosed
+}
+
+// DateTimeRange represents a range of dates and times,
+// like the Postgres tstzrange type.
+//
+// Each bound is either inclusive or exclusive, as described by Bounds.
+// The zero Bounds value is BoundsClosedOpen, so a range includes its start
+// and excludes its end by default. A range is empty if its start equals
+// its end and any of its bounds is exclusive.
+type DateTimeRange struct {
+	start  DateTime
+	end    DateTime
+	bounds Bounds
+}
+
+// NewDateTimeRange returns a new DateTimeRange [start, end).
+// It panics if end is before start.
+func NewDateTimeRange(start, end DateTime) DateTimeRange {
+	return NewDateTimeRangeBounds(start, end, BoundsClosedOpen)
+}
+
+// NewDateTimeRangeBounds returns a new DateTimeRange from start to end
+// with the given bounds. It panics if end is before start
+// or the bounds are out of range.
+func NewDateTimeRangeBounds(start, end DateTime, bounds Bounds) DateTimeRange {
+	if end.Before(start) {
+		panic(fmt.Sprintf("date and time range end %s is before start %s", end, start))
+	}
+	if int(bounds) >= len(boundsNames) {
+		panic(fmt.Sprintf("bounds %d is out of range", bounds))
+	}
+	return DateTimeRange{start: start, end: end, bounds: bounds}
+}
+
+func (r DateTimeRange) String() string {
+	if r.IsEmpty() {
+		return "empty"
+	}
+	b := r.bounds.String()
+	return b[:1] + r.start.String() + "," + r.end.String() + b[1:]
+}
+
+// Start returns the lower bound of the range.
+func (r DateTimeRange) Start() DateTime {
+	return r.start
+}
+
+// End returns the upper bound of the range.
+func (r DateTimeRange) End() DateTime {
+	return r.end
+}
+
+// Bounds returns whether the bounds of the range are inclusive.
+func (r DateTimeRange) Bounds() Bounds {
+	return r.bounds
+}
+
+// IsEmpty reports whether r contains no dates and times.
+func (r DateTimeRange) IsEmpty() bool {
+	return !r.start.Before(r.end) && r.bounds != BoundsClosed
+}
+
+// Duration returns the elapsed time between the bounds of the range.
+func (r DateTimeRange) Duration() Duration {
+	if r.IsEmpty() {
+		return Duration{}
+	}
+	return r.end.Sub(r.start)
+}
+
+// Contains reports whether the date and time dt is in the range.
+func (r DateTimeRange) Contains(dt DateTime) bool {
+	if r.bounds.lowerInc() && dt.Before(r.start) || !r.bounds.lowerInc() && !dt.After(r.start) {
+		return false
+	}
+	if r.bounds.upperInc() && dt.After(r.end) || !r.bounds.upperInc() && !dt.Before(r.end) {
+		return false
+	}
+	return true
+}
+
+// Overlaps reports whether r and u have at least one date and time in common.
+func (r DateTimeRange) Overlaps(u DateTimeRange) bool {
+	return !r.IsEmpty() && !u.IsEmpty() && r.startsBeforeEnd(u) && u.startsBeforeEnd(r)
+}
+
+// startsBeforeEnd reports whether the lower bound of r is before the upper bound of u.
+func (r DateTimeRange) startsBeforeEnd(u DateTimeRange) bool {
+	if r.start.Equal(u.end) {
+		return r.bounds.lowerInc() && u.bounds.upperInc()
+	}
+	return r.start.Before(u.end)
+}
+
+// Intersect returns the dates and times both in r and u.
+// It reports false if the ranges don't overlap.
+func (r DateTimeRange) Intersect(u DateTimeRange) (DateTimeRange, bool) {
+	if !r.Overlaps(u) {
+		return DateTimeRange{}, false
+	}
+
+	start, lowerInc := r.start, r.bounds.lowerInc()
+	switch {
+	case u.start.After(start):
+		start, lowerInc = u.start, u.bounds.lowerInc()
+	case u.start.Equal(start):
+		lowerInc = lowerInc && u.bounds.lowerInc()
+	}
+
+	end, upperInc := r.end, r.bounds.upperInc()
+	switch {
+	case u.end.Before(end):
+		end, upperInc = u.end, u.bounds.upperInc()
+	case u.end.Equal(end):
+		upperInc = upperInc && u.bounds.upperInc()
+	}
+	return DateTimeRange{start: start, end: end, bounds: newBounds(lowerInc, upperInc)}, true
+}
+
+// Split returns an iterator over consecutive buckets of the range, each
+// step long. The k-th bucket starts at the range start plus k*step, added
+// with DateTime.AddInterval in the timezone tz, so daily buckets keep
+// the local hour of the start across DST changes. Each bucket includes
+// its start and excludes its end, except that the first and last buckets
+// take the lower and upper bounds of the range, and the last bucket ends
+// at the range end. It returns an error if step is zero or has a negative
+// component.
+func (r DateTimeRange) Split(step Interval, tz Timezone) (iter.Seq[DateTimeRange], error) {
+	if step.IsZero() || step.year < 0 || step.month < 0 || step.day < 0 ||
+		step.hour < 0 || step.minute < 0 || step.second < 0 {
+		return nil, fmt.Errorf("timeapi: split step %s is not positive", step)
+	}
+
+	return func(yield func(DateTimeRange) bool) {
+		if r.IsEmpty() {
+			return
+		}
+		start, lowerInc := r.start, r.bounds.lowerInc()
+		for k := 1; ; k++ {
+			end := r.start.AddInterval(step.Mul(k), tz)
+			if !end.Before(r.end) {
+				yield(DateTimeRange{start: start, end: r.end, bounds: newBounds(lowerInc, r.bounds.upperInc())})
+				return
+			}
+			if !yield(DateTimeRange{start: start, end: end, bounds: newBounds(lowerInc, false)}) {
+				return
+			}
+			start, lowerInc = end, true
+		}
+	}, nil
+}
+
+type dateTimeRangeJSON struct {
+	Start  *DateTime `json:"start"`
+	End    *DateTime `json:"end"`
+	Bounds string    `json:"bounds,omitempty"`
+}
+
+// MarshalJSON implements the json.Marshaler interface. The range is written
+// as {"start":"2024-01-01T00:00:00Z","end":"2024-01-02T00:00:00Z","bounds":"[)"}.
+func (r DateTimeRange) MarshalJSON() ([]byte, error) {
+	return json.Marshal(dateTimeRangeJSON{Start: &r.start, End: &r.end, Bounds: r.bounds.String()})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It accepts the form written by MarshalJSON. A missing bounds
+// field means BoundsClosedOpen.
+func (r *DateTimeRange) UnmarshalJSON(b []byte) error {
+	var v dateTimeRangeJSON
+	if err := json.Unmarshal(b, &v); err != nil {
+		if errors.As(err, &ErrJsonValue{}) {
+			return err
+		}
+		return NewErrJsonValue(err)
+	}
+	if v.Start == nil || v.End == nil {
+		return NewErrJsonValue(fmt.Errorf("date and time range %s is invalid", string(b)))
+	}
+	if v.End.Before(*v.Start) {
+		return NewErrJsonValue(fmt.Errorf("date and time range end %s is before start %s", v.End, v.Start))
+	}
+
+	bounds := BoundsClosedOpen
+	if v.Bounds != "" {
+		i := slices.Index(boundsNames[:], v.Bounds)
+		if i < 0 {
+			return NewErrJsonValue(fmt.Errorf("bounds %q is invalid", v.Bounds))
+		}
+		bounds = Bounds(i)
+	}
+
+	*r = DateTimeRange{start: *v.Start, end: *v.End, bounds: bounds}
+	return nil
+}
+
+// Value implements the driver.Valuer interface. The range is written
+// in the Postgres tstzrange format, such as
+// "[2024-01-01T00:00:00Z,2024-01-02T00:00:00Z)".
+func (r DateTimeRange) Value() (driver.Value, error) {
+	return r.String(), nil
+}
+
+// Scan implements the sql.Scanner interface.
+// It accepts []byte and string values in the Postgres tstzrange format,
+// such as `["2024-01-01 00:00:00+00","2024-01-02 00:00:00+00")`.
+// The bounds are converted to UTC and truncated to seconds.
+// Ranges with infinite bounds are rejected.
+// Use sql.Null[DateTimeRange] for nullable columns.
+func (r *DateTimeRange) Scan(src any) error {
+	switch v := src.(type) {
+	case []byte:
+		return r.Scan(string(v))
+	case string:
+		dtr, err := parseDateTimeRange(v)
+		if err != nil {
+			return NewErrSqlValue(err)
+		}
+		*r = dtr
+		return nil
+	case nil:
+		return NewErrSqlValue(fmt.Errorf("date and time range cannot be null"))
+	default:
+		return NewErrSqlValue(fmt.Errorf("date and time range cannot be scanned from %T", src))
+	}
+}
+
+// parseDateTimeRange parses a Postgres tstzrange literal.
+func parseDateTimeRange(s string) (DateTimeRange, error) {
+	lower, upper, lowerInc, upperInc, empty, err := parseRange(s)
+	if err != nil {
+		return DateTimeRange{}, err
+	}
+	if empty {
+		return DateTimeRange{}, nil
+	}
+	if lower == "" || upper == "" {
+		return DateTimeRange{}, fmt.Errorf("timeapi: date and time range %q is unbounded", s)
+	}
+
+	lt, err := parseScanDateTime(lower)
+	if err != nil {
+		return DateTimeRange{}, err
+	}
+	ut, err := parseScanDateTime(upper)
+	if err != nil {
+		return DateTimeRange{}, err
+	}
+
+	start := DateTime{lt.UTC().Truncate(time.Second)}
+	end := DateTime{ut.UTC().Truncate(time.Second)}
+	if end.Before(start) {
+		return DateTimeRange{}, fmt.Errorf("timeapi: date and time range %q is invalid", s)
+	}
+	return DateTimeRange{start: start, end: end, bounds: newBounds(lowerInc, upperInc)}, nil
+}
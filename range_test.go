@@ -0,0 +1,471 @@
+package timeapi
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/krhubert/assert"
+)
+
+func TestDateRange(t *testing.T) {
+	jan := NewDateRange(NewDate(2024, 1, 1), NewDate(2024, 2, 1))
+	feb := NewClosedDateRange(NewDate(2024, 2, 1), NewDate(2024, 2, 29))
+	empty := NewDateRange(NewDate(2024, 1, 1), NewDate(2024, 1, 1))
+
+	t.Run("NewDateRange", func(t *testing.T) {
+		assert.Panic(t, func() { NewDateRange(NewDate(2024, 1, 2), NewDate(2024, 1, 1)) })
+		assert.Panic(t, func() { NewClosedDateRange(NewDate(2024, 1, 2), NewDate(2024, 1, 1)) })
+
+		r := NewClosedDateRange(NewDate(2024, 1, 1), NewDate(2024, 1, 31))
+		assert.Equal(t, r, jan)
+		assert.Equal(t, r.Start(), NewDate(2024, 1, 1))
+		assert.Equal(t, r.End(), NewDate(2024, 2, 1))
+		assert.Equal(t, r.Last(), NewDate(2024, 1, 31))
+		assert.False(t, r.IsEmpty())
+		assert.True(t, empty.IsEmpty())
+		assert.False(t, NewClosedDateRange(NewDate(2024, 1, 1), NewDate(2024, 1, 1)).IsEmpty())
+	})
+
+	t.Run("String", func(t *testing.T) {
+		assert.Equal(t, jan.String(), "[2024-01-01,2024-02-01)")
+		assert.Equal(t, feb.String(), "[2024-02-01,2024-03-01)")
+		assert.Equal(t, empty.String(), "empty")
+	})
+
+	t.Run("Equal", func(t *testing.T) {
+		assert.True(t, jan.Equal(NewClosedDateRange(NewDate(2024, 1, 1), NewDate(2024, 1, 31))))
+		assert.False(t, jan.Equal(feb))
+		assert.True(t, empty.Equal(DateRange{}))
+		assert.False(t, empty.Equal(jan))
+	})
+
+	t.Run("Days", func(t *testing.T) {
+		assert.Equal(t, jan.Days(), 31)
+		assert.Equal(t, feb.Days(), 29)
+		assert.Equal(t, empty.Days(), 0)
+	})
+
+	t.Run("Contains", func(t *testing.T) {
+		assert.True(t, jan.Contains(NewDate(2024, 1, 1)))
+		assert.True(t, jan.Contains(NewDate(2024, 1, 31)))
+		assert.False(t, jan.Contains(NewDate(2024, 2, 1)))
+		assert.False(t, jan.Contains(NewDate(2023, 12, 31)))
+		assert.False(t, empty.Contains(NewDate(2024, 1, 1)))
+	})
+
+	t.Run("Overlaps", func(t *testing.T) {
+		mid := NewDateRange(NewDate(2024, 1, 15), NewDate(2024, 2, 15))
+		assert.True(t, jan.Overlaps(mid))
+		assert.True(t, mid.Overlaps(feb))
+		assert.False(t, jan.Overlaps(feb))
+		assert.False(t, feb.Overlaps(jan))
+		assert.False(t, jan.Overlaps(empty))
+	})
+
+	t.Run("Intersect", func(t *testing.T) {
+		mid := NewDateRange(NewDate(2024, 1, 15), NewDate(2024, 2, 15))
+		r, ok := jan.Intersect(mid)
+		assert.True(t, ok)
+		assert.Equal(t, r, NewDateRange(NewDate(2024, 1, 15), NewDate(2024, 2, 1)))
+
+		_, ok = jan.Intersect(feb)
+		assert.False(t, ok)
+	})
+
+	t.Run("Union", func(t *testing.T) {
+		r, ok := jan.Union(feb)
+		assert.True(t, ok)
+		assert.Equal(t, r, NewDateRange(NewDate(2024, 1, 1), NewDate(2024, 3, 1)))
+
+		r, ok = feb.Union(empty)
+		assert.True(t, ok)
+		assert.Equal(t, r, feb)
+
+		mar := NewDateRange(NewDate(2024, 3, 1), NewDate(2024, 4, 1))
+		_, ok = jan.Union(mar)
+		assert.False(t, ok)
+	})
+
+	t.Run("Gap", func(t *testing.T) {
+		mar := NewDateRange(NewDate(2024, 3, 1), NewDate(2024, 4, 1))
+		r, ok := jan.Gap(mar)
+		assert.True(t, ok)
+		assert.Equal(t, r, feb)
+
+		r, ok = mar.Gap(jan)
+		assert.True(t, ok)
+		assert.Equal(t, r, feb)
+
+		_, ok = jan.Gap(feb)
+		assert.False(t, ok)
+		_, ok = jan.Gap(empty)
+		assert.False(t, ok)
+	})
+
+	t.Run("All", func(t *testing.T) {
+		r := NewClosedDateRange(NewDate(2024, 2, 28), NewDate(2024, 3, 1))
+		assert.Equal(t, slices.Collect(r.All()), []Date{
+			NewDate(2024, 2, 28),
+			NewDate(2024, 2, 29),
+			NewDate(2024, 3, 1),
+		})
+		assert.Equal(t, len(slices.Collect(empty.All())), 0)
+
+		for d := range jan.All() {
+			assert.Equal(t, d, NewDate(2024, 1, 1))
+			break
+		}
+	})
+
+	t.Run("MarshalJSON", func(t *testing.T) {
+		out, err := json.Marshal(jan)
+		assert.NoError(t, err)
+		assert.Equal(t, string(out), `{"start":"2024-01-01","end":"2024-02-01"}`)
+
+		out, err = json.Marshal(empty)
+		assert.NoError(t, err)
+		assert.Equal(t, string(out), `"empty"`)
+	})
+
+	t.Run("UnmarshalJSON", func(t *testing.T) {
+		var r DateRange
+		err := json.Unmarshal([]byte(`{"start":"2024-01-01","end":"2024-02-01"}`), &r)
+		assert.NoError(t, err)
+		assert.Equal(t, r, jan)
+
+		err = json.Unmarshal([]byte(`"empty"`), &r)
+		assert.NoError(t, err)
+		assert.Equal(t, r, DateRange{})
+
+		disjoint, ok := jan.Intersect(NewDateRange(NewDate(2024, 3, 1), NewDate(2024, 4, 1)))
+		assert.False(t, ok)
+		for _, in := range []DateRange{{}, disjoint, empty} {
+			out, err := json.Marshal(in)
+			assert.NoError(t, err)
+			var got DateRange
+			err = json.Unmarshal(out, &got)
+			assert.NoError(t, err)
+			assert.True(t, got.Equal(in))
+		}
+
+		err = json.Unmarshal([]byte(`{"start":"2024-01-01"}`), &r)
+		assert.ErrorContains(t, err, "is invalid")
+		err = json.Unmarshal([]byte(`{"start":"2024-02-01","end":"2024-01-01"}`), &r)
+		assert.ErrorContains(t, err, "is before start")
+		err = json.Unmarshal([]byte(`{"start":"2024-01","end":"2024-02-01"}`), &r)
+		assert.Error(t, err)
+	})
+
+	t.Run("Value", func(t *testing.T) {
+		v, err := jan.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, v, driver.Value("[2024-01-01,2024-02-01)"))
+
+		v, err = empty.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, v, driver.Value("empty"))
+	})
+
+	t.Run("Scan", func(t *testing.T) {
+		var r DateRange
+		err := r.Scan("[2024-01-01,2024-02-01)")
+		assert.NoError(t, err)
+		assert.Equal(t, r, jan)
+
+		err = r.Scan([]byte("[2024-01-01,2024-01-31]"))
+		assert.NoError(t, err)
+		assert.Equal(t, r, jan)
+
+		err = r.Scan(`("2023-12-31","2024-02-01")`)
+		assert.NoError(t, err)
+		assert.Equal(t, r, jan)
+
+		err = r.Scan("empty")
+		assert.NoError(t, err)
+		assert.True(t, r.IsEmpty())
+
+		for _, lit := range []string{"(2024-01-01,2024-01-01)", "[2024-01-01,2024-01-01)", "(2024-01-01,2024-01-01]", "(2024-01-01,2024-01-02)"} {
+			r = jan
+			err = r.Scan(lit)
+			assert.NoError(t, err)
+			assert.True(t, r.IsEmpty())
+		}
+
+		err = r.Scan("[2024-01-01,)")
+		assert.ErrorContains(t, err, "is unbounded")
+		err = r.Scan("[2024-02-01,2024-01-01)")
+		assert.ErrorContains(t, err, "is invalid")
+		err = r.Scan("(2024-01-02,2024-01-01)")
+		assert.ErrorContains(t, err, "is invalid")
+		err = r.Scan("[2024-01-01,2024-02-01")
+		assert.ErrorContains(t, err, "invalid range")
+		err = r.Scan("[2024-01,2024-02-01)")
+		assert.Error(t, err)
+		err = r.Scan(nil)
+		assert.ErrorContains(t, err, "cannot be null")
+		err = r.Scan(int64(1))
+		assert.ErrorContains(t, err, "cannot be scanned from int64")
+	})
+}
+
+func TestTimeRange(t *testing.T) {
+	day := NewTimeRange(NewTime(9, 0, 0), NewTime(17, 0, 0))
+	night := NewTimeRange(NewTime(22, 0, 0), NewTime(2, 0, 0))
+	full := NewTimeRange(NewTime(0, 0, 0), NewTime(0, 0, 0))
+
+	t.Run("String", func(t *testing.T) {
+		assert.Equal(t, day.String(), "09:00:00-17:00:00")
+		assert.Equal(t, night.String(), "22:00:00-02:00:00")
+	})
+
+	t.Run("CrossesMidnight", func(t *testing.T) {
+		assert.False(t, day.CrossesMidnight())
+		assert.True(t, night.CrossesMidnight())
+		assert.False(t, full.CrossesMidnight())
+		assert.True(t, full.IsFullDay())
+		assert.False(t, night.IsFullDay())
+	})
+
+	t.Run("Duration", func(t *testing.T) {
+		assert.Equal(t, day.Duration(), NewDuration(8, 0, 0))
+		assert.Equal(t, night.Duration(), NewDuration(4, 0, 0))
+		assert.Equal(t, full.Duration(), NewDuration(24, 0, 0))
+		assert.Equal(t, NewTimeRange(NewTime(0, 0, 1), NewTime(0, 0, 0)).Duration(), NewDuration(23, 59, 59))
+	})
+
+	t.Run("Contains", func(t *testing.T) {
+		assert.True(t, day.Contains(NewTime(9, 0, 0)))
+		assert.True(t, day.Contains(NewTime(16, 59, 59)))
+		assert.False(t, day.Contains(NewTime(17, 0, 0)))
+		assert.False(t, day.Contains(NewTime(8, 59, 59)))
+
+		assert.True(t, night.Contains(NewTime(22, 0, 0)))
+		assert.True(t, night.Contains(NewTime(23, 59, 59)))
+		assert.True(t, night.Contains(NewTime(0, 0, 0)))
+		assert.True(t, night.Contains(NewTime(1, 59, 59)))
+		assert.False(t, night.Contains(NewTime(2, 0, 0)))
+		assert.False(t, night.Contains(NewTime(12, 0, 0)))
+		assert.False(t, night.Contains(NewTime(21, 59, 59)))
+
+		assert.True(t, full.Contains(NewTime(12, 0, 0)))
+		assert.True(t, full.Contains(NewTime(23, 59, 59)))
+	})
+
+	t.Run("Overlaps", func(t *testing.T) {
+		assert.False(t, day.Overlaps(night))
+		assert.False(t, night.Overlaps(day))
+		assert.True(t, night.Overlaps(NewTimeRange(NewTime(1, 0, 0), NewTime(3, 0, 0))))
+		assert.True(t, night.Overlaps(NewTimeRange(NewTime(21, 0, 0), NewTime(23, 0, 0))))
+		assert.True(t, night.Overlaps(NewTimeRange(NewTime(23, 0, 0), NewTime(1, 0, 0))))
+		assert.False(t, night.Overlaps(NewTimeRange(NewTime(2, 0, 0), NewTime(22, 0, 0))))
+		assert.True(t, day.Overlaps(NewTimeRange(NewTime(16, 0, 0), NewTime(10, 0, 0))))
+		assert.True(t, full.Overlaps(day))
+		assert.True(t, night.Overlaps(full))
+	})
+
+	t.Run("ParseTimeRange", func(t *testing.T) {
+		r, err := ParseTimeRange("22:00:00-02:00:00")
+		assert.NoError(t, err)
+		assert.Equal(t, r, night)
+
+		_, err = ParseTimeRange("22:00:00")
+		assert.ErrorContains(t, err, "invalid time range")
+		_, err = ParseTimeRange("22:00-02:00")
+		assert.ErrorContains(t, err, "invalid time range")
+		_, err = ParseTimeRange("22:00:00-24:00:00")
+		assert.ErrorContains(t, err, "invalid time range")
+		_, err = ParseTimeRange("22:00:00 - 02:00:00")
+		assert.ErrorContains(t, err, "invalid time range")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		out, err := json.Marshal(night)
+		assert.NoError(t, err)
+		assert.Equal(t, string(out), `"22:00:00-02:00:00"`)
+
+		var r TimeRange
+		err = json.Unmarshal(out, &r)
+		assert.NoError(t, err)
+		assert.Equal(t, r, night)
+
+		err = json.Unmarshal([]byte(`"22:00:00"`), &r)
+		assert.ErrorContains(t, err, "invalid time range")
+	})
+}
+
+func TestDateTimeRange(t *testing.T) {
+	t0 := NewDateTime(2024, 1, 1, 0, 0, 0)
+	t1 := NewDateTime(2024, 1, 1, 12, 0, 0)
+	t2 := NewDateTime(2024, 1, 2, 0, 0, 0)
+	day := NewDateTimeRange(t0, t2)
+
+	t.Run("NewDateTimeRange", func(t *testing.T) {
+		assert.Panic(t, func() { NewDateTimeRange(t2, t0) })
+		assert.Panic(t, func() { NewDateTimeRangeBounds(t0, t2, Bounds(4)) })
+
+		assert.Equal(t, day.Start(), t0)
+		assert.Equal(t, day.End(), t2)
+		assert.Equal(t, day.Bounds(), BoundsClosedOpen)
+		assert.False(t, day.IsEmpty())
+		assert.True(t, DateTimeRange{}.IsEmpty())
+		assert.True(t, NewDateTimeRangeBounds(t0, t0, BoundsOpenClosed).IsEmpty())
+		assert.False(t, NewDateTimeRangeBounds(t0, t0, BoundsClosed).IsEmpty())
+	})
+
+	t.Run("String", func(t *testing.T) {
+		assert.Equal(t, day.String(), "[2024-01-01T00:00:00Z,2024-01-02T00:00:00Z)")
+		assert.Equal(t, NewDateTimeRangeBounds(t0, t2, BoundsOpenClosed).String(), "(2024-01-01T00:00:00Z,2024-01-02T00:00:00Z]")
+		assert.Equal(t, NewDateTimeRange(t0, t0).String(), "empty")
+		assert.Equal(t, BoundsOpen.String(), "()")
+		assert.Equal(t, Bounds(7).String(), "Bounds(7)")
+	})
+
+	t.Run("Duration", func(t *testing.T) {
+		assert.Equal(t, day.Duration(), NewDuration(24, 0, 0))
+		assert.Equal(t, NewDateTimeRangeBounds(t0, t0, BoundsClosed).Duration(), Duration{})
+		assert.Equal(t, NewDateTimeRange(t0, t0).Duration(), Duration{})
+	})
+
+	t.Run("Contains", func(t *testing.T) {
+		assert.True(t, day.Contains(t0))
+		assert.True(t, day.Contains(t1))
+		assert.False(t, day.Contains(t2))
+		assert.False(t, day.Contains(t0.Add(NewDuration(0, 0, -1))))
+
+		r := NewDateTimeRangeBounds(t0, t2, BoundsOpenClosed)
+		assert.False(t, r.Contains(t0))
+		assert.True(t, r.Contains(t2))
+		assert.True(t, NewDateTimeRangeBounds(t0, t0, BoundsClosed).Contains(t0))
+	})
+
+	t.Run("Overlaps", func(t *testing.T) {
+		assert.True(t, day.Overlaps(NewDateTimeRange(t1, t2.Add(NewDuration(1, 0, 0)))))
+		assert.False(t, day.Overlaps(NewDateTimeRange(t2, t2.Add(NewDuration(1, 0, 0)))))
+		assert.True(t, NewDateTimeRangeBounds(t0, t1, BoundsClosed).Overlaps(NewDateTimeRangeBounds(t1, t2, BoundsClosed)))
+		assert.False(t, NewDateTimeRangeBounds(t0, t1, BoundsClosed).Overlaps(NewDateTimeRangeBounds(t1, t2, BoundsOpen)))
+		assert.False(t, day.Overlaps(NewDateTimeRange(t1, t1)))
+	})
+
+	t.Run("Intersect", func(t *testing.T) {
+		r, ok := day.Intersect(NewDateTimeRangeBounds(t1, t2, BoundsClosed))
+		assert.True(t, ok)
+		assert.Equal(t, r, NewDateTimeRange(t1, t2))
+
+		r, ok = NewDateTimeRangeBounds(t0, t1, BoundsClosed).Intersect(NewDateTimeRangeBounds(t1, t2, BoundsClosed))
+		assert.True(t, ok)
+		assert.Equal(t, r, NewDateTimeRangeBounds(t1, t1, BoundsClosed))
+
+		r, ok = NewDateTimeRangeBounds(t0, t2, BoundsOpen).Intersect(NewDateTimeRangeBounds(t0, t1, BoundsClosed))
+		assert.True(t, ok)
+		assert.Equal(t, r, NewDateTimeRangeBounds(t0, t1, BoundsOpenClosed))
+
+		_, ok = day.Intersect(NewDateTimeRange(t2, t2.Add(NewDuration(1, 0, 0))))
+		assert.False(t, ok)
+	})
+
+	t.Run("Split", func(t *testing.T) {
+		seq, err := NewDateTimeRangeBounds(t0, t2, BoundsClosed).Split(NewIntervalTime(10, 0, 0), NewTimezone(*time.UTC))
+		assert.NoError(t, err)
+		assert.Equal(t, slices.Collect(seq), []DateTimeRange{
+			NewDateTimeRange(t0, NewDateTime(2024, 1, 1, 10, 0, 0)),
+			NewDateTimeRange(NewDateTime(2024, 1, 1, 10, 0, 0), NewDateTime(2024, 1, 1, 20, 0, 0)),
+			NewDateTimeRangeBounds(NewDateTime(2024, 1, 1, 20, 0, 0), t2, BoundsClosed),
+		})
+
+		loc, err := time.LoadLocation("America/New_York")
+		assert.NoError(t, err)
+		r := NewDateTimeRange(NewDateTime(2024, 3, 9, 5, 0, 0), NewDateTime(2024, 3, 12, 4, 0, 0))
+		seq, err = r.Split(NewIntervalDate(0, 0, 1), NewTimezone(*loc))
+		assert.NoError(t, err)
+		days := slices.Collect(seq)
+		assert.Equal(t, days, []DateTimeRange{
+			NewDateTimeRange(NewDateTime(2024, 3, 9, 5, 0, 0), NewDateTime(2024, 3, 10, 5, 0, 0)),
+			NewDateTimeRange(NewDateTime(2024, 3, 10, 5, 0, 0), NewDateTime(2024, 3, 11, 4, 0, 0)),
+			NewDateTimeRange(NewDateTime(2024, 3, 11, 4, 0, 0), NewDateTime(2024, 3, 12, 4, 0, 0)),
+		})
+		assert.Equal(t, days[1].Duration(), NewDuration(23, 0, 0))
+
+		r = NewDateTimeRange(NewDateTime(2024, 1, 31, 0, 0, 0), NewDateTime(2024, 4, 30, 0, 0, 0))
+		seq, err = r.Split(NewIntervalDate(0, 1, 0), NewTimezone(*time.UTC))
+		assert.NoError(t, err)
+		var starts []DateTime
+		for b := range seq {
+			starts = append(starts, b.Start())
+		}
+		assert.Equal(t, starts, []DateTime{
+			NewDateTime(2024, 1, 31, 0, 0, 0),
+			NewDateTime(2024, 2, 29, 0, 0, 0),
+			NewDateTime(2024, 3, 31, 0, 0, 0),
+		})
+
+		seq, err = NewDateTimeRange(t0, t0).Split(NewIntervalTime(1, 0, 0), NewTimezone(*time.UTC))
+		assert.NoError(t, err)
+		assert.Equal(t, len(slices.Collect(seq)), 0)
+
+		_, err = day.Split(Interval{}, NewTimezone(*time.UTC))
+		assert.ErrorContains(t, err, "is not positive")
+		_, err = day.Split(NewIntervalDate(0, 1, -1), NewTimezone(*time.UTC))
+		assert.ErrorContains(t, err, "is not positive")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		out, err := json.Marshal(day)
+		assert.NoError(t, err)
+		assert.Equal(t, string(out), `{"start":"2024-01-01T00:00:00Z","end":"2024-01-02T00:00:00Z","bounds":"[)"}`)
+
+		var r DateTimeRange
+		err = json.Unmarshal([]byte(`{"start":"2024-01-01T00:00:00Z","end":"2024-01-02T00:00:00Z","bounds":"(]"}`), &r)
+		assert.NoError(t, err)
+		assert.Equal(t, r, NewDateTimeRangeBounds(t0, t2, BoundsOpenClosed))
+
+		err = json.Unmarshal([]byte(`{"start":"2024-01-01T00:00:00Z","end":"2024-01-02T00:00:00Z"}`), &r)
+		assert.NoError(t, err)
+		assert.Equal(t, r, day)
+
+		err = json.Unmarshal([]byte(`{"start":"2024-01-01T00:00:00Z","end":"2024-01-02T00:00:00Z","bounds":"[["}`), &r)
+		assert.ErrorContains(t, err, "bounds \"[[\" is invalid")
+		err = json.Unmarshal([]byte(`{"end":"2024-01-02T00:00:00Z"}`), &r)
+		assert.ErrorContains(t, err, "is invalid")
+		err = json.Unmarshal([]byte(`{"start":"2024-01-02T00:00:00Z","end":"2024-01-01T00:00:00Z"}`), &r)
+		assert.ErrorContains(t, err, "is before start")
+	})
+
+	t.Run("Value", func(t *testing.T) {
+		v, err := day.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, v, driver.Value("[2024-01-01T00:00:00Z,2024-01-02T00:00:00Z)"))
+	})
+
+	t.Run("Scan", func(t *testing.T) {
+		var r DateTimeRange
+		err := r.Scan(`["2024-01-01 00:00:00+00","2024-01-02 00:00:00+00")`)
+		assert.NoError(t, err)
+		assert.Equal(t, r, day)
+
+		err = r.Scan([]byte(`("2024-01-01 01:00:00.75+01","2024-01-02 00:00:00+00"]`))
+		assert.NoError(t, err)
+		assert.Equal(t, r, NewDateTimeRangeBounds(t0, t2, BoundsOpenClosed))
+
+		err = r.Scan("[2024-01-01T00:00:00Z,2024-01-02T00:00:00Z)")
+		assert.NoError(t, err)
+		assert.Equal(t, r, day)
+
+		err = r.Scan("empty")
+		assert.NoError(t, err)
+		assert.True(t, r.IsEmpty())
+
+		err = r.Scan(`["2024-01-01 00:00:00+00",)`)
+		assert.ErrorContains(t, err, "is unbounded")
+		err = r.Scan(`["2024-01-02 00:00:00+00","2024-01-01 00:00:00+00")`)
+		assert.ErrorContains(t, err, "is invalid")
+		err = r.Scan(`["2024-01-01","2024-01-02")`)
+		assert.ErrorContains(t, err, "is invalid")
+		err = r.Scan(nil)
+		assert.ErrorContains(t, err, "cannot be null")
+		err = r.Scan(int64(1))
+		assert.ErrorContains(t, err, "cannot be scanned from int64")
+	})
+}
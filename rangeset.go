@@ -0,0 +1,440 @@
+package timeapi
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// DateRangeSet represents a set of dates as a sorted list of
+// non-empty ranges that neither overlap nor are adjacent,
+// like the Postgres datemultirange type.
+//
+// The zero value is an empty set. Operations on a set
+// return a new set and never modify the receiver.
+type DateRangeSet struct {
+	ranges []DateRange
+}
+
+// NewDateRangeSet returns a new DateRangeSet with the dates of the given ranges.
+// Overlapping and adjacent ranges are merged and empty ranges are dropped.
+func NewDateRangeSet(ranges ...DateRange) DateRangeSet {
+	rs := make([]DateRange, 0, len(ranges))
+	for _, r := range ranges {
+		if !r.IsEmpty() {
+			rs = append(rs, r)
+		}
+	}
+	slices.SortFunc(rs, func(a, b DateRange) int {
+		return a.start.Sub(b.start)
+	})
+
+	n := 0
+	for _, r := range rs {
+		if n > 0 && !rs[n-1].end.Before(r.start) {
+			rs[n-1].end = maxDate(rs[n-1].end, r.end)
+			continue
+		}
+		rs[n] = r
+		n++
+	}
+	if n == 0 {
+		// the empty set is always the zero value
+		return DateRangeSet{}
+	}
+	return DateRangeSet{ranges: slices.Clip(rs[:n])}
+}
+
+func (s DateRangeSet) String() string {
+	parts := make([]string, len(s.ranges))
+	for i, r := range s.ranges {
+		parts[i] = r.String()
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// Ranges returns the ranges of the set in ascending order.
+func (s DateRangeSet) Ranges() []DateRange {
+	return slices.Clone(s.ranges)
+}
+
+// IsEmpty reports whether s contains no dates.
+func (s DateRangeSet) IsEmpty() bool {
+	return len(s.ranges) == 0
+}
+
+// Find returns the range of the set that contains the date d.
+// It reports false if d is not in the set.
+func (s DateRangeSet) Find(d Date) (DateRange, bool) {
+	i := sort.Search(len(s.ranges), func(i int) bool {
+		return s.ranges[i].end.After(d)
+	})
+	if i < len(s.ranges) && s.ranges[i].Contains(d) {
+		return s.ranges[i], true
+	}
+	return DateRange{}, false
+}
+
+// Contains reports whether the date d is in the set.
+func (s DateRangeSet) Contains(d Date) bool {
+	_, ok := s.Find(d)
+	return ok
+}
+
+// Add returns the set of dates in s or r.
+func (s DateRangeSet) Add(r DateRange) DateRangeSet {
+	return NewDateRangeSet(append(s.Ranges(), r)...)
+}
+
+// Subtract returns the set of dates in s but not in r.
+func (s DateRangeSet) Subtract(r DateRange) DateRangeSet {
+	var rs []DateRange
+	for _, x := range s.ranges {
+		if !x.Overlaps(r) {
+			rs = append(rs, x)
+			continue
+		}
+		if x.start.Before(r.start) {
+			rs = append(rs, DateRange{start: x.start, end: r.start})
+		}
+		if r.end.Before(x.end) {
+			rs = append(rs, DateRange{start: r.end, end: x.end})
+		}
+	}
+	return DateRangeSet{ranges: rs}
+}
+
+// Intersect returns the set of dates both in s and t.
+func (s DateRangeSet) Intersect(t DateRangeSet) DateRangeSet {
+	var rs []DateRange
+	for i, j := 0, 0; i < len(s.ranges) && j < len(t.ranges); {
+		if r, ok := s.ranges[i].Intersect(t.ranges[j]); ok {
+			rs = append(rs, r)
+		}
+		if s.ranges[i].end.Before(t.ranges[j].end) {
+			i++
+		} else {
+			j++
+		}
+	}
+	return DateRangeSet{ranges: rs}
+}
+
+// Complement returns the set of dates in within but not in s.
+func (s DateRangeSet) Complement(within DateRange) DateRangeSet {
+	var rs []DateRange
+	cursor := within.start
+	for _, x := range s.Intersect(NewDateRangeSet(within)).ranges {
+		if cursor.Before(x.start) {
+			rs = append(rs, DateRange{start: cursor, end: x.start})
+		}
+		cursor = x.end
+	}
+	if cursor.Before(within.end) {
+		rs = append(rs, DateRange{start: cursor, end: within.end})
+	}
+	return DateRangeSet{ranges: rs}
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The set is written as an array of ranges, as written by DateRange.MarshalJSON.
+func (s DateRangeSet) MarshalJSON() ([]byte, error) {
+	if s.ranges == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(s.ranges)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It accepts an array of ranges, which are merged as in NewDateRangeSet.
+func (s *DateRangeSet) UnmarshalJSON(b []byte) error {
+	var rs []DateRange
+	if err := json.Unmarshal(b, &rs); err != nil {
+		if errors.As(err, &ErrJsonValue{}) {
+			return err
+		}
+		return NewErrJsonValue(err)
+	}
+	*s = NewDateRangeSet(rs...)
+	return nil
+}
+
+// Value implements the driver.Valuer interface. The set is written in
+// the Postgres datemultirange format, such as "{[2024-01-01,2024-01-05)}".
+func (s DateRangeSet) Value() (driver.Value, error) {
+	return s.String(), nil
+}
+
+// Scan implements the sql.Scanner interface.
+// It accepts []byte and string values in the Postgres datemultirange format.
+// Use sql.Null[DateRangeSet] for nullable columns.
+func (s *DateRangeSet) Scan(src any) error {
+	switch v := src.(type) {
+	case []byte:
+		return s.Scan(string(v))
+	case string:
+		lits, err := parseMultirange(v)
+		if err != nil {
+			return NewErrSqlValue(err)
+		}
+		rs := make([]DateRange, len(lits))
+		for i, lit := range lits {
+			if rs[i], err = parseDateRange(lit); err != nil {
+				return NewErrSqlValue(err)
+			}
+		}
+		*s = NewDateRangeSet(rs...)
+		return nil
+	case nil:
+		return NewErrSqlValue(fmt.Errorf("date range set cannot be null"))
+	default:
+		return NewErrSqlValue(fmt.Errorf("date range set cannot be scanned from %T", src))
+	}
+}
+
+// DateTimeRangeSet represents a set of dates and times as a sorted list
+// of non-empty ranges that neither overlap nor are adjacent,
+// like the Postgres tstzmultirange type.
+//
+// The zero value is an empty set. Operations on a set
+// return a new set and never modify the receiver.
+type DateTimeRangeSet struct {
+	ranges []DateTimeRange
+}
+
+// NewDateTimeRangeSet returns a new DateTimeRangeSet with the dates and times
+// of the given ranges. Overlapping and adjacent ranges are merged
+// and empty ranges are dropped.
+func NewDateTimeRangeSet(ranges ...DateTimeRange) DateTimeRangeSet {
+	rs := make([]DateTimeRange, 0, len(ranges))
+	for _, r := range ranges {
+		if !r.IsEmpty() {
+			rs = append(rs, r)
+		}
+	}
+	slices.SortFunc(rs, compareLower)
+
+	n := 0
+	for _, r := range rs {
+		if n > 0 && rs[n-1].touches(r) {
+			if compareUpper(r, rs[n-1]) > 0 {
+				rs[n-1] = DateTimeRange{
+					start:  rs[n-1].start,
+					end:    r.end,
+					bounds: newBounds(rs[n-1].bounds.lowerInc(), r.bounds.upperInc()),
+				}
+			}
+			continue
+		}
+		rs[n] = r
+		n++
+	}
+	if n == 0 {
+		// the empty set is always the zero value
+		return DateTimeRangeSet{}
+	}
+	return DateTimeRangeSet{ranges: slices.Clip(rs[:n])}
+}
+
+func (s DateTimeRangeSet) String() string {
+	parts := make([]string, len(s.ranges))
+	for i, r := range s.ranges {
+		parts[i] = r.String()
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// Ranges returns the ranges of the set in ascending order.
+func (s DateTimeRangeSet) Ranges() []DateTimeRange {
+	return slices.Clone(s.ranges)
+}
+
+// IsEmpty reports whether s contains no dates and times.
+func (s DateTimeRangeSet) IsEmpty() bool {
+	return len(s.ranges) == 0
+}
+
+// Find returns the range of the set that contains the date and time dt.
+// It reports false if dt is not in the set.
+func (s DateTimeRangeSet) Find(dt DateTime) (DateTimeRange, bool) {
+	i := sort.Search(len(s.ranges), func(i int) bool {
+		return !s.ranges[i].end.Before(dt)
+	})
+	if i < len(s.ranges) && s.ranges[i].Contains(dt) {
+		return s.ranges[i], true
+	}
+	return DateTimeRange{}, false
+}
+
+// Contains reports whether the date and time dt is in the set.
+func (s DateTimeRangeSet) Contains(dt DateTime) bool {
+	_, ok := s.Find(dt)
+	return ok
+}
+
+// Add returns the set of dates and times in s or r.
+func (s DateTimeRangeSet) Add(r DateTimeRange) DateTimeRangeSet {
+	return NewDateTimeRangeSet(append(s.Ranges(), r)...)
+}
+
+// Subtract returns the set of dates and times in s but not in r.
+func (s DateTimeRangeSet) Subtract(r DateTimeRange) DateTimeRangeSet {
+	var rs []DateTimeRange
+	for _, x := range s.ranges {
+		if !x.Overlaps(r) {
+			rs = append(rs, x)
+			continue
+		}
+		if left, ok := rangeFromBounds(x.start, x.bounds.lowerInc(), r.start, !r.bounds.lowerInc()); ok {
+			rs = append(rs, left)
+		}
+		if right, ok := rangeFromBounds(r.end, !r.bounds.upperInc(), x.end, x.bounds.upperInc()); ok {
+			rs = append(rs, right)
+		}
+	}
+	return DateTimeRangeSet{ranges: rs}
+}
+
+// Intersect returns the set of dates and times both in s and t.
+func (s DateTimeRangeSet) Intersect(t DateTimeRangeSet) DateTimeRangeSet {
+	var rs []DateTimeRange
+	for i, j := 0, 0; i < len(s.ranges) && j < len(t.ranges); {
+		if r, ok := s.ranges[i].Intersect(t.ranges[j]); ok {
+			rs = append(rs, r)
+		}
+		if compareUpper(s.ranges[i], t.ranges[j]) < 0 {
+			i++
+		} else {
+			j++
+		}
+	}
+	return DateTimeRangeSet{ranges: rs}
+}
+
+// Complement returns the set of dates and times in within but not in s.
+func (s DateTimeRangeSet) Complement(within DateTimeRange) DateTimeRangeSet {
+	var rs []DateTimeRange
+	cursor, inc := within.start, within.bounds.lowerInc()
+	for _, x := range s.Intersect(NewDateTimeRangeSet(within)).ranges {
+		if r, ok := rangeFromBounds(cursor, inc, x.start, !x.bounds.lowerInc()); ok {
+			rs = append(rs, r)
+		}
+		cursor, inc = x.end, !x.bounds.upperInc()
+	}
+	if r, ok := rangeFromBounds(cursor, inc, within.end, within.bounds.upperInc()); ok {
+		rs = append(rs, r)
+	}
+	return DateTimeRangeSet{ranges: rs}
+}
+
+// MarshalJSON implements the json.Marshaler interface. The set is
+// written as an array of ranges, as written by DateTimeRange.MarshalJSON.
+func (s DateTimeRangeSet) MarshalJSON() ([]byte, error) {
+	if s.ranges == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(s.ranges)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It accepts an array of ranges, which are merged as in NewDateTimeRangeSet.
+func (s *DateTimeRangeSet) UnmarshalJSON(b []byte) error {
+	var rs []DateTimeRange
+	if err := json.Unmarshal(b, &rs); err != nil {
+		if errors.As(err, &ErrJsonValue{}) {
+			return err
+		}
+		return NewErrJsonValue(err)
+	}
+	*s = NewDateTimeRangeSet(rs...)
+	return nil
+}
+
+// Value implements the driver.Valuer interface. The set is written in
+// the Postgres tstzmultirange format, such as
+// "{[2024-01-01T00:00:00Z,2024-01-02T00:00:00Z)}".
+func (s DateTimeRangeSet) Value() (driver.Value, error) {
+	return s.String(), nil
+}
+
+// Scan implements the sql.Scanner interface.
+// It accepts []byte and string values in the Postgres tstzmultirange format.
+// Use sql.Null[DateTimeRangeSet] for nullable columns.
+func (s *DateTimeRangeSet) Scan(src any) error {
+	switch v := src.(type) {
+	case []byte:
+		return s.Scan(string(v))
+	case string:
+		lits, err := parseMultirange(v)
+		if err != nil {
+			return NewErrSqlValue(err)
+		}
+		rs := make([]DateTimeRange, len(lits))
+		for i, lit := range lits {
+			if rs[i], err = parseDateTimeRange(lit); err != nil {
+				return NewErrSqlValue(err)
+			}
+		}
+		*s = NewDateTimeRangeSet(rs...)
+		return nil
+	case nil:
+		return NewErrSqlValue(fmt.Errorf("date and time range set cannot be null"))
+	default:
+		return NewErrSqlValue(fmt.Errorf("date and time range set cannot be scanned from %T", src))
+	}
+}
+
+// rangeFromBounds returns the range from start to end with the given
+// bounds. It reports false if the range would be invalid or empty.
+func rangeFromBounds(start DateTime, lowerInc bool, end DateTime, upperInc bool) (DateTimeRange, bool) {
+	if end.Before(start) {
+		return DateTimeRange{}, false
+	}
+	r := DateTimeRange{start: start, end: end, bounds: newBounds(lowerInc, upperInc)}
+	return r, !r.IsEmpty()
+}
+
+// touches reports whether r and u, where u doesn't start before r,
+// overlap or are adjacent, so their union is a single range.
+func (r DateTimeRange) touches(u DateTimeRange) bool {
+	if r.end.Equal(u.start) {
+		return r.bounds.upperInc() || u.bounds.lowerInc()
+	}
+	return u.start.Before(r.end)
+}
+
+// compareLower compares the lower bounds of r and u.
+// An inclusive bound is before an exclusive one at the same time.
+func compareLower(r, u DateTimeRange) int {
+	if c := r.start.t.Compare(u.start.t); c != 0 {
+		return c
+	}
+	switch ri, ui := r.bounds.lowerInc(), u.bounds.lowerInc(); {
+	case ri == ui:
+		return 0
+	case ri:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// compareUpper compares the upper bounds of r and u.
+// An exclusive bound is before an inclusive one at the same time.
+func compareUpper(r, u DateTimeRange) int {
+	if c := r.end.t.Compare(u.end.t); c != 0 {
+		return c
+	}
+	switch ri, ui := r.bounds.upperInc(), u.bounds.upperInc(); {
+	case ri == ui:
+		return 0
+	case ri:
+		return 1
+	default:
+		return -1
+	}
+}
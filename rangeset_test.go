@@ -0,0 +1,289 @@
+package timeapi
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+
+	"github.com/krhubert/assert"
+)
+
+func TestDateRangeSet(t *testing.T) {
+	d := func(day int) Date { return NewDate(2024, 1, day) }
+	r := func(start, end int) DateRange { return NewDateRange(d(start), d(end)) }
+
+	t.Run("NewDateRangeSet", func(t *testing.T) {
+		s := NewDateRangeSet(r(10, 12), r(1, 3), r(2, 5), r(5, 6), r(8, 8), r(20, 25), r(21, 22))
+		assert.Equal(t, s.Ranges(), []DateRange{r(1, 6), r(10, 12), r(20, 25)})
+		assert.False(t, s.IsEmpty())
+		assert.True(t, NewDateRangeSet().IsEmpty())
+		assert.True(t, NewDateRangeSet(r(1, 1)).IsEmpty())
+		assert.True(t, DateRangeSet{}.IsEmpty())
+	})
+
+	t.Run("String", func(t *testing.T) {
+		assert.Equal(t, NewDateRangeSet(r(1, 3), r(5, 6)).String(), "{[2024-01-01,2024-01-03),[2024-01-05,2024-01-06)}")
+		assert.Equal(t, DateRangeSet{}.String(), "{}")
+	})
+
+	t.Run("Find", func(t *testing.T) {
+		s := NewDateRangeSet(r(1, 3), r(5, 8), r(10, 12))
+		got, ok := s.Find(d(6))
+		assert.True(t, ok)
+		assert.Equal(t, got, r(5, 8))
+
+		got, ok = s.Find(d(1))
+		assert.True(t, ok)
+		assert.Equal(t, got, r(1, 3))
+
+		_, ok = s.Find(d(3))
+		assert.False(t, ok)
+		_, ok = s.Find(d(12))
+		assert.False(t, ok)
+		_, ok = DateRangeSet{}.Find(d(1))
+		assert.False(t, ok)
+
+		assert.True(t, s.Contains(d(11)))
+		assert.False(t, s.Contains(d(9)))
+	})
+
+	t.Run("Add", func(t *testing.T) {
+		s := NewDateRangeSet(r(1, 3), r(5, 8))
+		assert.Equal(t, s.Add(r(3, 5)).Ranges(), []DateRange{r(1, 8)})
+		assert.Equal(t, s.Add(r(10, 11)).Ranges(), []DateRange{r(1, 3), r(5, 8), r(10, 11)})
+		assert.Equal(t, s.Ranges(), []DateRange{r(1, 3), r(5, 8)})
+	})
+
+	t.Run("Subtract", func(t *testing.T) {
+		s := NewDateRangeSet(r(1, 10), r(15, 20))
+		assert.Equal(t, s.Subtract(r(3, 5)).Ranges(), []DateRange{r(1, 3), r(5, 10), r(15, 20)})
+		assert.Equal(t, s.Subtract(r(8, 17)).Ranges(), []DateRange{r(1, 8), r(17, 20)})
+		assert.Equal(t, s.Subtract(r(1, 20)).Ranges(), []DateRange(nil))
+		assert.Equal(t, s.Subtract(r(3, 3)).Ranges(), []DateRange{r(1, 10), r(15, 20)})
+	})
+
+	t.Run("Intersect", func(t *testing.T) {
+		s := NewDateRangeSet(r(1, 5), r(8, 12), r(15, 20))
+		u := NewDateRangeSet(r(3, 9), r(11, 16), r(19, 25))
+		assert.Equal(t, s.Intersect(u).Ranges(), []DateRange{r(3, 5), r(8, 9), r(11, 12), r(15, 16), r(19, 20)})
+		assert.Equal(t, u.Intersect(s), s.Intersect(u))
+		assert.True(t, s.Intersect(DateRangeSet{}).IsEmpty())
+	})
+
+	t.Run("Complement", func(t *testing.T) {
+		busy := NewDateRangeSet(r(1, 3), r(5, 8), r(20, 25))
+		assert.Equal(t, busy.Complement(r(2, 22)).Ranges(), []DateRange{r(3, 5), r(8, 20)})
+		assert.Equal(t, busy.Complement(r(1, 3)).Ranges(), []DateRange(nil))
+		assert.Equal(t, DateRangeSet{}.Complement(r(1, 3)).Ranges(), []DateRange{r(1, 3)})
+		assert.True(t, busy.Complement(r(4, 4)).IsEmpty())
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		s := NewDateRangeSet(r(1, 5), r(8, 12))
+		for _, e := range []DateRangeSet{
+			NewDateRangeSet(),
+			NewDateRangeSet(r(1, 1)),
+			s.Subtract(r(1, 12)),
+			s.Intersect(NewDateRangeSet(r(5, 8))),
+			s.Complement(r(1, 5)),
+			DateRangeSet{}.Add(r(3, 3)),
+		} {
+			assert.Equal(t, e, DateRangeSet{})
+			out, err := json.Marshal(e)
+			assert.NoError(t, err)
+			assert.Equal(t, string(out), `[]`)
+		}
+
+		var e DateRangeSet
+		err := json.Unmarshal([]byte(`[]`), &e)
+		assert.NoError(t, err)
+		assert.Equal(t, e, DateRangeSet{})
+		err = e.Scan("{}")
+		assert.NoError(t, err)
+		assert.Equal(t, e, DateRangeSet{})
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		out, err := json.Marshal(NewDateRangeSet(r(1, 3)))
+		assert.NoError(t, err)
+		assert.Equal(t, string(out), `[{"start":"2024-01-01","end":"2024-01-03"}]`)
+
+		out, err = json.Marshal(DateRangeSet{})
+		assert.NoError(t, err)
+		assert.Equal(t, string(out), `[]`)
+
+		var s DateRangeSet
+		err = json.Unmarshal([]byte(`[{"start":"2024-01-05","end":"2024-01-06"},{"start":"2024-01-01","end":"2024-01-05"}]`), &s)
+		assert.NoError(t, err)
+		assert.Equal(t, s.Ranges(), []DateRange{r(1, 6)})
+
+		err = json.Unmarshal([]byte(`[{"start":"2024-01-05","end":"2024-01-01"}]`), &s)
+		assert.ErrorContains(t, err, "is before start")
+		err = json.Unmarshal([]byte(`{}`), &s)
+		assert.Error(t, err)
+	})
+
+	t.Run("Value", func(t *testing.T) {
+		v, err := NewDateRangeSet(r(1, 3), r(5, 6)).Value()
+		assert.NoError(t, err)
+		assert.Equal(t, v, driver.Value("{[2024-01-01,2024-01-03),[2024-01-05,2024-01-06)}"))
+	})
+
+	t.Run("Scan", func(t *testing.T) {
+		var s DateRangeSet
+		err := s.Scan("{[2024-01-01,2024-01-03), [2024-01-05,2024-01-05], empty}")
+		assert.NoError(t, err)
+		assert.Equal(t, s.Ranges(), []DateRange{r(1, 3), r(5, 6)})
+
+		err = s.Scan([]byte("{}"))
+		assert.NoError(t, err)
+		assert.True(t, s.IsEmpty())
+
+		err = s.Scan("{[2024-01-01,2024-01-03),}")
+		assert.ErrorContains(t, err, "invalid multirange")
+		err = s.Scan("[2024-01-01,2024-01-03)")
+		assert.ErrorContains(t, err, "invalid multirange")
+		err = s.Scan("{[2024-01-01,)}")
+		assert.ErrorContains(t, err, "is unbounded")
+		err = s.Scan(nil)
+		assert.ErrorContains(t, err, "cannot be null")
+		err = s.Scan(int64(1))
+		assert.ErrorContains(t, err, "cannot be scanned from int64")
+	})
+}
+
+func TestDateTimeRangeSet(t *testing.T) {
+	h := func(hour int) DateTime { return NewDateTime(2024, 1, 1, hour, 0, 0) }
+	r := func(start, end int) DateTimeRange { return NewDateTimeRange(h(start), h(end)) }
+	rb := func(start, end int, b Bounds) DateTimeRange { return NewDateTimeRangeBounds(h(start), h(end), b) }
+
+	t.Run("NewDateTimeRangeSet", func(t *testing.T) {
+		s := NewDateTimeRangeSet(r(10, 12), r(1, 3), r(2, 5), r(5, 6), r(8, 8), rb(20, 22, BoundsOpen), rb(22, 23, BoundsOpen))
+		assert.Equal(t, s.Ranges(), []DateTimeRange{r(1, 6), r(10, 12), rb(20, 22, BoundsOpen), rb(22, 23, BoundsOpen)})
+
+		s = NewDateTimeRangeSet(rb(1, 3, BoundsClosed), rb(3, 5, BoundsOpen), rb(1, 2, BoundsOpenClosed))
+		assert.Equal(t, s.Ranges(), []DateTimeRange{rb(1, 5, BoundsClosedOpen)})
+
+		s = NewDateTimeRangeSet(rb(1, 3, BoundsOpen), rb(1, 2, BoundsClosed))
+		assert.Equal(t, s.Ranges(), []DateTimeRange{rb(1, 3, BoundsClosedOpen)})
+
+		assert.True(t, NewDateTimeRangeSet(r(1, 1)).IsEmpty())
+		assert.True(t, DateTimeRangeSet{}.IsEmpty())
+	})
+
+	t.Run("String", func(t *testing.T) {
+		assert.Equal(t, NewDateTimeRangeSet(rb(1, 3, BoundsClosed)).String(), "{[2024-01-01T01:00:00Z,2024-01-01T03:00:00Z]}")
+		assert.Equal(t, DateTimeRangeSet{}.String(), "{}")
+	})
+
+	t.Run("Find", func(t *testing.T) {
+		s := NewDateTimeRangeSet(r(1, 3), rb(3, 5, BoundsOpenClosed), r(10, 12))
+		got, ok := s.Find(h(4))
+		assert.True(t, ok)
+		assert.Equal(t, got, rb(3, 5, BoundsOpenClosed))
+
+		got, ok = s.Find(h(5))
+		assert.True(t, ok)
+		assert.Equal(t, got, rb(3, 5, BoundsOpenClosed))
+
+		_, ok = s.Find(h(3))
+		assert.False(t, ok)
+		_, ok = s.Find(h(12))
+		assert.False(t, ok)
+		assert.True(t, s.Contains(h(1)))
+		assert.False(t, s.Contains(h(0)))
+	})
+
+	t.Run("Add", func(t *testing.T) {
+		s := NewDateTimeRangeSet(r(1, 3), rb(3, 5, BoundsOpenClosed))
+		assert.Equal(t, s.Add(rb(3, 3, BoundsClosed)).Ranges(), []DateTimeRange{rb(1, 5, BoundsClosed)})
+		assert.Equal(t, s.Add(r(6, 7)).Ranges(), []DateTimeRange{r(1, 3), rb(3, 5, BoundsOpenClosed), r(6, 7)})
+	})
+
+	t.Run("Subtract", func(t *testing.T) {
+		s := NewDateTimeRangeSet(r(1, 10), r(15, 20))
+		assert.Equal(t, s.Subtract(r(3, 5)).Ranges(), []DateTimeRange{r(1, 3), r(5, 10), r(15, 20)})
+		assert.Equal(t, s.Subtract(rb(3, 5, BoundsOpen)).Ranges(), []DateTimeRange{rb(1, 3, BoundsClosed), r(5, 10), r(15, 20)})
+		assert.Equal(t, s.Subtract(r(8, 17)).Ranges(), []DateTimeRange{r(1, 8), r(17, 20)})
+		assert.Equal(t, s.Subtract(rb(1, 20, BoundsOpen)).Ranges(), []DateTimeRange{rb(1, 1, BoundsClosed)})
+	})
+
+	t.Run("Intersect", func(t *testing.T) {
+		s := NewDateTimeRangeSet(r(1, 5), rb(8, 12, BoundsClosed))
+		u := NewDateTimeRangeSet(r(3, 9), rb(12, 16, BoundsClosed))
+		assert.Equal(t, s.Intersect(u).Ranges(), []DateTimeRange{r(3, 5), r(8, 9), rb(12, 12, BoundsClosed)})
+		assert.Equal(t, u.Intersect(s), s.Intersect(u))
+	})
+
+	t.Run("Complement", func(t *testing.T) {
+		busy := NewDateTimeRangeSet(r(9, 10), rb(12, 13, BoundsClosed), r(17, 20))
+		free := busy.Complement(r(8, 18))
+		assert.Equal(t, free.Ranges(), []DateTimeRange{r(8, 9), r(10, 12), rb(13, 17, BoundsOpen)})
+		assert.Equal(t, DateTimeRangeSet{}.Complement(rb(8, 18, BoundsClosed)).Ranges(), []DateTimeRange{rb(8, 18, BoundsClosed)})
+		assert.True(t, busy.Complement(r(9, 10)).IsEmpty())
+		assert.Equal(t, busy.Complement(rb(9, 10, BoundsClosed)).Ranges(), []DateTimeRange{rb(10, 10, BoundsClosed)})
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		s := NewDateTimeRangeSet(r(1, 5), r(8, 12))
+		for _, e := range []DateTimeRangeSet{
+			NewDateTimeRangeSet(),
+			NewDateTimeRangeSet(r(1, 1)),
+			s.Subtract(rb(1, 12, BoundsClosed)),
+			s.Intersect(NewDateTimeRangeSet(r(5, 8))),
+			s.Complement(r(1, 5)),
+			DateTimeRangeSet{}.Add(r(3, 3)),
+		} {
+			assert.Equal(t, e, DateTimeRangeSet{})
+			out, err := json.Marshal(e)
+			assert.NoError(t, err)
+			assert.Equal(t, string(out), `[]`)
+		}
+
+		var e DateTimeRangeSet
+		err := json.Unmarshal([]byte(`[]`), &e)
+		assert.NoError(t, err)
+		assert.Equal(t, e, DateTimeRangeSet{})
+		err = e.Scan("{}")
+		assert.NoError(t, err)
+		assert.Equal(t, e, DateTimeRangeSet{})
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		out, err := json.Marshal(NewDateTimeRangeSet(r(1, 3)))
+		assert.NoError(t, err)
+		assert.Equal(t, string(out), `[{"start":"2024-01-01T01:00:00Z","end":"2024-01-01T03:00:00Z","bounds":"[)"}]`)
+
+		var s DateTimeRangeSet
+		err = json.Unmarshal(out, &s)
+		assert.NoError(t, err)
+		assert.Equal(t, s.Ranges(), []DateTimeRange{r(1, 3)})
+
+		err = json.Unmarshal([]byte(`[{"start":"2024-01-01T01:00:00Z"}]`), &s)
+		assert.ErrorContains(t, err, "is invalid")
+	})
+
+	t.Run("Value", func(t *testing.T) {
+		v, err := NewDateTimeRangeSet(r(1, 3)).Value()
+		assert.NoError(t, err)
+		assert.Equal(t, v, driver.Value("{[2024-01-01T01:00:00Z,2024-01-01T03:00:00Z)}"))
+	})
+
+	t.Run("Scan", func(t *testing.T) {
+		var s DateTimeRangeSet
+		err := s.Scan(`{["2024-01-01 01:00:00+00","2024-01-01 03:00:00+00"),["2024-01-01 03:00:00+00","2024-01-01 05:00:00+00"]}`)
+		assert.NoError(t, err)
+		assert.Equal(t, s.Ranges(), []DateTimeRange{rb(1, 5, BoundsClosed)})
+
+		err = s.Scan([]byte("{}"))
+		assert.NoError(t, err)
+		assert.True(t, s.IsEmpty())
+
+		err = s.Scan(`{["2024-01-01 01:00:00+00","2024-01-01 03:00:00+00"}`)
+		assert.ErrorContains(t, err, "invalid multirange")
+		err = s.Scan(nil)
+		assert.ErrorContains(t, err, "cannot be null")
+		err = s.Scan(int64(1))
+		assert.ErrorContains(t, err, "cannot be scanned from int64")
+	})
+}
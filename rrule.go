@@ -0,0 +1,429 @@
+package timeapi
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is how often a recurrence rule repeats, as in the FREQ rule part of RFC 5545.
+type Frequency uint8
+
+const (
+	FreqSecondly Frequency = iota + 1
+	FreqMinutely
+	FreqHourly
+	FreqDaily
+	FreqWeekly
+	FreqMonthly
+	FreqYearly
+)
+
+var frequencyNames = [...]string{
+	FreqSecondly: "SECONDLY",
+	FreqMinutely: "MINUTELY",
+	FreqHourly:   "HOURLY",
+	FreqDaily:    "DAILY",
+	FreqWeekly:   "WEEKLY",
+	FreqMonthly:  "MONTHLY",
+	FreqYearly:   "YEARLY",
+}
+
+func (f Frequency) String() string {
+	if f >= FreqSecondly && f <= FreqYearly {
+		return frequencyNames[f]
+	}
+	return fmt.Sprintf("Frequency(%d)", uint8(f))
+}
+
+// rruleUntilLayout is the layout of the UNTIL rule part.
+const rruleUntilLayout = "20060102T150405Z"
+
+// maxYear is the last year in which recurrence rules and cron expressions are evaluated.
+const maxYear = 9999
+
+// RRuleDay is an entry of the BYDAY rule part: a weekday, optionally
+// with its ordinal within the month or the year, such as 2MO for
+// the second Monday or -1FR for the last Friday.
+type RRuleDay struct {
+	// N is the ordinal of the weekday, negative if counted from the end.
+	// Zero means every such weekday.
+	N       int
+	Weekday Weekday
+}
+
+func (d RRuleDay) String() string {
+	if d.N == 0 {
+		return weekdayShortNames[d.Weekday.w]
+	}
+	return strconv.Itoa(d.N) + weekdayShortNames[d.Weekday.w]
+}
+
+// RRule represents a recurrence rule of RFC 5545, such as
+// "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1" for the last weekday
+// of every month. Use ParseRRule to parse a rule and RRule.Expand
+// to list its occurrences.
+//
+// Only the FREQ, INTERVAL, COUNT, UNTIL, BYMONTH, BYMONTHDAY, BYDAY,
+// BYSETPOS and WKST rule parts are supported.
+type RRule struct {
+	Freq Frequency
+	// Interval is the number of periods between occurrences. Zero means 1.
+	Interval int
+	// Count is the number of occurrences. Zero means no limit.
+	Count int
+	// Until is the last date and time an occurrence may have. The zero
+	// DateTime means no limit. Count and Until can't be used together.
+	Until      DateTime
+	ByMonth    []time.Month
+	ByMonthDay []int
+	ByDay      []RRuleDay
+	BySetPos   []int
+	// WeekStart is the first day of the week, which matters for weekly
+	// rules with an interval. RFC 5545 defaults it to Monday, which is
+	// what ParseRRule does, but the zero Weekday is Sunday.
+	WeekStart Weekday
+}
+
+// String returns the rule in the RFC 5545 RRULE format, such as
+// "FREQ=WEEKLY;BYDAY=MO,FR". The zero RRule is written as "".
+func (r RRule) String() string {
+	if r.isZero() {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("FREQ=" + r.Freq.String())
+	if r.Interval > 1 {
+		b.WriteString(";INTERVAL=" + strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		b.WriteString(";COUNT=" + strconv.Itoa(r.Count))
+	}
+	if !r.Until.t.IsZero() {
+		b.WriteString(";UNTIL=" + r.Until.t.UTC().Format(rruleUntilLayout))
+	}
+	writeList(&b, "BYMONTH", r.ByMonth, func(m time.Month) string { return strconv.Itoa(int(m)) })
+	writeList(&b, "BYMONTHDAY", r.ByMonthDay, strconv.Itoa)
+	writeList(&b, "BYDAY", r.ByDay, RRuleDay.String)
+	writeList(&b, "BYSETPOS", r.BySetPos, strconv.Itoa)
+	if r.WeekStart.w != time.Monday {
+		b.WriteString(";WKST=" + weekdayShortNames[r.WeekStart.w])
+	}
+	return b.String()
+}
+
+// isZero reports whether r is the zero RRule.
+func (r RRule) isZero() bool {
+	return r.Freq == 0 && r.Interval == 0 && r.Count == 0 && r.Until.t.IsZero() &&
+		len(r.ByMonth) == 0 && len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 &&
+		len(r.BySetPos) == 0 && r.WeekStart.w == time.Sunday
+}
+
+// writeList writes the rule part name with the values
+// formatted by f, if there are any values.
+func writeList[T any](b *strings.Builder, name string, values []T, f func(T) string) {
+	if len(values) == 0 {
+		return
+	}
+	b.WriteString(";" + name + "=")
+	for i, v := range values {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(f(v))
+	}
+}
+
+// Expand returns an iterator over the occurrences of the rule from start,
+// in ascending order. The rule is applied to the wall clock time of start
+// in the timezone tz, so an occurrence keeps the local time of start
+// across DST changes. A wall clock time skipped or repeated by a DST change
+// is resolved as in time.Date. Hourly, minutely and secondly rules instead
+// step by elapsed time from start.
+//
+// Start is the first occurrence only if it matches the rule. Expansion
+// stops after the year 9999. It returns an error if the rule is invalid.
+func (r RRule) Expand(start DateTime, tz Timezone) (iter.Seq[DateTime], error) {
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+
+	return func(yield func(DateTime) bool) {
+		count := 0
+		emit := func(t time.Time) bool {
+			if t.Before(start.t) {
+				return true
+			}
+			if !r.Until.t.IsZero() && t.After(r.Until.t) {
+				return false
+			}
+			if !yield(DateTime{t.UTC()}) {
+				return false
+			}
+			count++
+			return r.Count == 0 || count < r.Count
+		}
+
+		if r.Freq >= FreqDaily {
+			r.expandDays(start.t.In(tz.GoLocation()), emit)
+		} else {
+			r.expandTime(start.t, tz.GoLocation(), emit)
+		}
+	}, nil
+}
+
+// expandDays expands a daily or less frequent rule from the local time start.
+func (r RRule) expandDays(start time.Time, emit func(time.Time) bool) {
+	if len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 {
+		switch r.Freq {
+		case FreqYearly:
+			if len(r.ByMonth) == 0 {
+				r.ByMonth = []time.Month{start.Month()}
+			}
+			r.ByMonthDay = []int{start.Day()}
+		case FreqMonthly:
+			r.ByMonthDay = []int{start.Day()}
+		case FreqWeekly:
+			r.ByDay = []RRuleDay{{Weekday: NewWeekday(start.Weekday())}}
+		}
+	}
+
+	interval := max(r.Interval, 1)
+	hour, min, sec := start.Clock()
+	startDay := daysFromCivil(start.Date())
+
+	var days []int
+	for k := 0; ; k++ {
+		// the period is the days [first, last)
+		var first, last int
+		switch r.Freq {
+		case FreqYearly:
+			year := start.Year() + k*interval
+			first, last = daysFromCivil(year, time.January, 1), daysFromCivil(year+1, time.January, 1)
+		case FreqMonthly:
+			year, month, _ := addMonths(start.Year(), start.Month(), 1, k*interval, MonthClamp)
+			first = daysFromCivil(year, month, 1)
+			last = first + daysIn(year, month)
+		case FreqWeekly:
+			first = startDay - floorMod(weekdayOf(startDay)-int(r.WeekStart.w), 7) + 7*k*interval
+			last = first + 7
+		default:
+			first = startDay + k*interval
+			last = first + 1
+		}
+		if year, _, _ := civilFromDays(first); year > maxYear {
+			return
+		}
+
+		days = days[:0]
+		for d := first; d < last; d++ {
+			if r.matchDay(d) {
+				days = append(days, d)
+			}
+		}
+		for _, d := range r.setPos(days) {
+			year, month, day := civilFromDays(d)
+			if !emit(time.Date(year, month, day, hour, min, sec, 0, start.Location())) {
+				return
+			}
+		}
+	}
+}
+
+// expandTime expands an hourly or more frequent rule from start.
+func (r RRule) expandTime(start time.Time, loc *time.Location, emit func(time.Time) bool) {
+	step := int64(max(r.Interval, 1))
+	switch r.Freq {
+	case FreqHourly:
+		step *= 3600
+	case FreqMinutely:
+		step *= 60
+	}
+
+	for k := int64(0); ; {
+		t := time.Unix(start.Unix()+k*step, 0).In(loc)
+		year, month, day := t.Date()
+		if year > maxYear {
+			return
+		}
+		if !r.matchDay(daysFromCivil(year, month, day)) {
+			// skip to the first occurrence on the next day
+			next := time.Date(year, month, day+1, 0, 0, 0, 0, loc).Unix()
+			k = (next - start.Unix() + step - 1) / step
+			continue
+		}
+		if !emit(t) {
+			return
+		}
+		k++
+	}
+}
+
+// matchDay reports whether the day, as returned by daysFromCivil,
+// matches the BYMONTH, BYMONTHDAY and BYDAY rule parts.
+func (r RRule) matchDay(days int) bool {
+	year, month, day := civilFromDays(days)
+	if len(r.ByMonth) > 0 && !slices.Contains(r.ByMonth, month) {
+		return false
+	}
+	if len(r.ByMonthDay) > 0 && !slices.ContainsFunc(r.ByMonthDay, func(md int) bool {
+		return md == day || md == day-daysIn(year, month)-1
+	}) {
+		return false
+	}
+	if len(r.ByDay) == 0 {
+		return true
+	}
+
+	// the ordinal of a weekday is within the year only
+	// for yearly rules without BYMONTH
+	pos, n := day, daysIn(year, month)
+	if r.Freq == FreqYearly && len(r.ByMonth) == 0 {
+		first := daysFromCivil(year, time.January, 1)
+		pos, n = days-first+1, daysFromCivil(year+1, time.January, 1)-first
+	}
+	wd := time.Weekday(weekdayOf(days))
+	return slices.ContainsFunc(r.ByDay, func(bd RRuleDay) bool {
+		return bd.Weekday.w == wd && (bd.N == 0 || bd.N == (pos-1)/7+1 || bd.N == -((n-pos)/7+1))
+	})
+}
+
+// setPos returns the days of a period selected by the BYSETPOS rule part.
+func (r RRule) setPos(days []int) []int {
+	if len(r.BySetPos) == 0 {
+		return days
+	}
+	var out []int
+	for _, p := range r.BySetPos {
+		i := p - 1
+		if p < 0 {
+			i = len(days) + p
+		}
+		if i >= 0 && i < len(days) {
+			out = append(out, days[i])
+		}
+	}
+	slices.Sort(out)
+	return slices.Compact(out)
+}
+
+// validate reports an error if the rule parts are out of range
+// or used in combinations not allowed by RFC 5545.
+func (r RRule) validate() error {
+	if r.Freq < FreqSecondly || r.Freq > FreqYearly {
+		return fmt.Errorf("timeapi: rrule frequency %s is invalid", r.Freq)
+	}
+	if r.Interval < 0 {
+		return fmt.Errorf("timeapi: rrule interval %d is invalid", r.Interval)
+	}
+	if r.Count < 0 {
+		return fmt.Errorf("timeapi: rrule count %d is invalid", r.Count)
+	}
+	if r.Count > 0 && !r.Until.t.IsZero() {
+		return fmt.Errorf("timeapi: rrule can't have both COUNT and UNTIL")
+	}
+	for _, m := range r.ByMonth {
+		if m < time.January || m > time.December {
+			return fmt.Errorf("timeapi: rrule BYMONTH value %d is out of range", m)
+		}
+	}
+	for _, md := range r.ByMonthDay {
+		if md == 0 || md < -31 || md > 31 {
+			return fmt.Errorf("timeapi: rrule BYMONTHDAY value %d is out of range", md)
+		}
+	}
+	if len(r.ByMonthDay) > 0 && r.Freq == FreqWeekly {
+		return fmt.Errorf("timeapi: rrule BYMONTHDAY can't be used with FREQ=WEEKLY")
+	}
+	for _, bd := range r.ByDay {
+		if bd.Weekday.w < time.Sunday || bd.Weekday.w > time.Saturday {
+			return fmt.Errorf("timeapi: rrule BYDAY weekday %d is out of range", bd.Weekday.w)
+		}
+		if bd.N < -53 || bd.N > 53 {
+			return fmt.Errorf("timeapi: rrule BYDAY value %s is out of range", bd)
+		}
+		if bd.N != 0 && r.Freq != FreqMonthly && r.Freq != FreqYearly {
+			return fmt.Errorf("timeapi: rrule BYDAY value %s can't be used with FREQ=%s", bd, r.Freq)
+		}
+	}
+	for _, sp := range r.BySetPos {
+		if sp == 0 || sp < -366 || sp > 366 {
+			return fmt.Errorf("timeapi: rrule BYSETPOS value %d is out of range", sp)
+		}
+	}
+	if len(r.BySetPos) > 0 {
+		if len(r.ByMonth) == 0 && len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+			return fmt.Errorf("timeapi: rrule BYSETPOS requires another BYxxx rule part")
+		}
+		if r.Freq < FreqDaily {
+			return fmt.Errorf("timeapi: rrule BYSETPOS can't be used with FREQ=%s", r.Freq)
+		}
+	}
+	if r.WeekStart.w < time.Sunday || r.WeekStart.w > time.Saturday {
+		return fmt.Errorf("timeapi: rrule WKST weekday %d is out of range", r.WeekStart.w)
+	}
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The zero RRule is written as "".
+func (r RRule) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + r.String() + `"`), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It accepts the rules parsed by ParseRRule and "" as the zero RRule.
+func (r *RRule) UnmarshalJSON(b []byte) error {
+	// strip quotes
+	if len(b) < 2 {
+		return NewErrJsonValue(fmt.Errorf("rrule %q is invalid", string(b)))
+	}
+	b = b[1 : len(b)-1]
+	if len(b) == 0 {
+		*r = RRule{}
+		return nil
+	}
+
+	rule, err := ParseRRule(string(b))
+	if err != nil {
+		return NewErrJsonValue(err)
+	}
+	*r = rule
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+// The rule is written in the RFC 5545 RRULE format.
+func (r RRule) Value() (driver.Value, error) {
+	return r.String(), nil
+}
+
+// Scan implements the sql.Scanner interface.
+// It accepts []byte and string values in the format parsed by ParseRRule
+// and "" as the zero RRule. Use sql.Null[RRule] for nullable columns.
+func (r *RRule) Scan(src any) error {
+	switch v := src.(type) {
+	case []byte:
+		return r.Scan(string(v))
+	case string:
+		if v == "" {
+			*r = RRule{}
+			return nil
+		}
+		rule, err := ParseRRule(v)
+		if err != nil {
+			return NewErrSqlValue(err)
+		}
+		*r = rule
+		return nil
+	case nil:
+		return NewErrSqlValue(fmt.Errorf("rrule cannot be null"))
+	default:
+		return NewErrSqlValue(fmt.Errorf("rrule cannot be scanned from %T", src))
+	}
+}
@@ -0,0 +1,407 @@
+package timeapi
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/krhubert/assert"
+)
+
+func TestRRule(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+	ny := NewTimezone(*loc)
+
+	// local parses a wall clock time in New York. A date
+	// without a time, such as "19970902", means 09:00.
+	local := func(s string) DateTime {
+		if len(s) == 8 {
+			s += "T090000"
+		}
+		tm, err := time.ParseInLocation("20060102T150405", s, loc)
+		assert.NoError(t, err)
+		return DateTime{tm.UTC()}
+	}
+
+	// expand returns at most n occurrences of the rule as local times.
+	expand := func(t *testing.T, rule, start string, n int) []string {
+		t.Helper()
+		r, err := ParseRRule(rule)
+		assert.NoError(t, err)
+		seq, err := r.Expand(local(start), ny)
+		assert.NoError(t, err)
+
+		out := []string{}
+		for dt := range seq {
+			if len(out) == n {
+				break
+			}
+			s := dt.GoTime().In(loc).Format("20060102T150405")
+			if s[8:] == "T090000" {
+				s = s[:8]
+			}
+			out = append(out, s)
+		}
+		return out
+	}
+
+	// RFC 5545, section 3.8.5.3, with DTSTART in America/New_York.
+	t.Run("RFC5545", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			rule  string
+			start string
+			want  []string
+		}{
+			{
+				"daily for 10 occurrences",
+				"FREQ=DAILY;COUNT=10", "19970902",
+				[]string{"19970902", "19970903", "19970904", "19970905", "19970906", "19970907", "19970908", "19970909", "19970910", "19970911"},
+			},
+			{
+				"every other day",
+				"FREQ=DAILY;INTERVAL=2", "19970902",
+				[]string{"19970902", "19970904", "19970906", "19970908", "19970910"},
+			},
+			{
+				"every 10 days, 5 occurrences",
+				"FREQ=DAILY;INTERVAL=10;COUNT=5", "19970902",
+				[]string{"19970902", "19970912", "19970922", "19971002", "19971012"},
+			},
+			{
+				"weekly for 10 occurrences",
+				"FREQ=WEEKLY;COUNT=10", "19970902",
+				[]string{"19970902", "19970909", "19970916", "19970923", "19970930", "19971007", "19971014", "19971021", "19971028", "19971104"},
+			},
+			{
+				"weekly on Tuesday and Thursday for five weeks",
+				"FREQ=WEEKLY;COUNT=10;WKST=SU;BYDAY=TU,TH", "19970902",
+				[]string{"19970902", "19970904", "19970909", "19970911", "19970916", "19970918", "19970923", "19970925", "19970930", "19971002"},
+			},
+			{
+				"every other week on Monday, Wednesday and Friday until December 24, 1997",
+				"FREQ=WEEKLY;INTERVAL=2;UNTIL=19971224T000000Z;WKST=SU;BYDAY=MO,WE,FR", "19970901",
+				[]string{
+					"19970901", "19970903", "19970905", "19970915", "19970917", "19970919", "19970929",
+					"19971001", "19971003", "19971013", "19971015", "19971017", "19971027", "19971029", "19971031",
+					"19971110", "19971112", "19971114", "19971124", "19971126", "19971128",
+					"19971208", "19971210", "19971212", "19971222",
+				},
+			},
+			{
+				"every other week on Tuesday and Thursday, for 8 occurrences",
+				"FREQ=WEEKLY;INTERVAL=2;COUNT=8;WKST=SU;BYDAY=TU,TH", "19970902",
+				[]string{"19970902", "19970904", "19970916", "19970918", "19970930", "19971002", "19971014", "19971016"},
+			},
+			{
+				"monthly on the first Friday for 10 occurrences",
+				"FREQ=MONTHLY;COUNT=10;BYDAY=1FR", "19970905",
+				[]string{"19970905", "19971003", "19971107", "19971205", "19980102", "19980206", "19980306", "19980403", "19980501", "19980605"},
+			},
+			{
+				"every other month on the first and last Sunday of the month for 10 occurrences",
+				"FREQ=MONTHLY;INTERVAL=2;COUNT=10;BYDAY=1SU,-1SU", "19970907",
+				[]string{"19970907", "19970928", "19971102", "19971130", "19980104", "19980125", "19980301", "19980329", "19980503", "19980531"},
+			},
+			{
+				"monthly on the second-to-last Monday of the month for 6 months",
+				"FREQ=MONTHLY;COUNT=6;BYDAY=-2MO", "19970922",
+				[]string{"19970922", "19971020", "19971117", "19971222", "19980119", "19980216"},
+			},
+			{
+				"monthly on the third-to-the-last day of the month",
+				"FREQ=MONTHLY;BYMONTHDAY=-3", "19970928",
+				[]string{"19970928", "19971029", "19971128", "19971229", "19980129", "19980226"},
+			},
+			{
+				"monthly on the 2nd and 15th of the month for 10 occurrences",
+				"FREQ=MONTHLY;COUNT=10;BYMONTHDAY=2,15", "19970902",
+				[]string{"19970902", "19970915", "19971002", "19971015", "19971102", "19971115", "19971202", "19971215", "19980102", "19980115"},
+			},
+			{
+				"monthly on the first and last day of the month for 10 occurrences",
+				"FREQ=MONTHLY;COUNT=10;BYMONTHDAY=1,-1", "19970930",
+				[]string{"19970930", "19971001", "19971031", "19971101", "19971130", "19971201", "19971231", "19980101", "19980131", "19980201"},
+			},
+			{
+				"every 18 months on the 10th thru 15th of the month for 10 occurrences",
+				"FREQ=MONTHLY;INTERVAL=18;COUNT=10;BYMONTHDAY=10,11,12,13,14,15", "19970910",
+				[]string{"19970910", "19970911", "19970912", "19970913", "19970914", "19970915", "19990310", "19990311", "19990312", "19990313"},
+			},
+			{
+				"every Tuesday, every other month",
+				"FREQ=MONTHLY;INTERVAL=2;BYDAY=TU", "19970902",
+				[]string{
+					"19970902", "19970909", "19970916", "19970923", "19970930",
+					"19971104", "19971111", "19971118", "19971125",
+					"19980106", "19980113", "19980120", "19980127",
+					"19980303", "19980310", "19980317", "19980324", "19980331",
+				},
+			},
+			{
+				"yearly in June and July for 10 occurrences",
+				"FREQ=YEARLY;COUNT=10;BYMONTH=6,7", "19970610",
+				[]string{"19970610", "19970710", "19980610", "19980710", "19990610", "19990710", "20000610", "20000710", "20010610", "20010710"},
+			},
+			{
+				"every other year on January, February, and March for 10 occurrences",
+				"FREQ=YEARLY;INTERVAL=2;COUNT=10;BYMONTH=1,2,3", "19970310",
+				[]string{"19970310", "19990110", "19990210", "19990310", "20010110", "20010210", "20010310", "20030110", "20030210", "20030310"},
+			},
+			{
+				"every 20th Monday of the year",
+				"FREQ=YEARLY;BYDAY=20MO", "19970519",
+				[]string{"19970519", "19980518", "19990517"},
+			},
+			{
+				"every Thursday in March",
+				"FREQ=YEARLY;BYMONTH=3;BYDAY=TH", "19970313",
+				[]string{"19970313", "19970320", "19970327", "19980305", "19980312", "19980319", "19980326", "19990304", "19990311", "19990318", "19990325"},
+			},
+			{
+				"every Thursday, but only during June, July, and August",
+				"FREQ=YEARLY;BYDAY=TH;BYMONTH=6,7,8", "19970605",
+				[]string{
+					"19970605", "19970612", "19970619", "19970626", "19970703", "19970710", "19970717",
+					"19970724", "19970731", "19970807", "19970814", "19970821", "19970828", "19980604",
+				},
+			},
+			{
+				"every Friday the 13th",
+				"FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13", "19970902",
+				[]string{"19980213", "19980313", "19981113", "19990813", "20001013"},
+			},
+			{
+				"the first Saturday that follows the first Sunday of the month",
+				"FREQ=MONTHLY;BYDAY=SA;BYMONTHDAY=7,8,9,10,11,12,13", "19970913",
+				[]string{"19970913", "19971011", "19971108", "19971213", "19980110", "19980207", "19980307", "19980411", "19980509", "19980613"},
+			},
+			{
+				"every 4 years, the first Tuesday after a Monday in November",
+				"FREQ=YEARLY;INTERVAL=4;BYMONTH=11;BYDAY=TU;BYMONTHDAY=2,3,4,5,6,7,8", "19961105",
+				[]string{"19961105", "20001107", "20041102"},
+			},
+			{
+				"the third instance into the month of one of Tuesday, Wednesday, or Thursday, for the next 3 months",
+				"FREQ=MONTHLY;COUNT=3;BYDAY=TU,WE,TH;BYSETPOS=3", "19970904",
+				[]string{"19970904", "19971007", "19971106"},
+			},
+			{
+				"the second-to-last weekday of the month",
+				"FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-2", "19970929",
+				[]string{"19970929", "19971030", "19971127", "19971230", "19980129", "19980226", "19980330"},
+			},
+			{
+				"every 15 minutes for 6 occurrences",
+				"FREQ=MINUTELY;INTERVAL=15;COUNT=6", "19970902",
+				[]string{"19970902", "19970902T091500", "19970902T093000", "19970902T094500", "19970902T100000", "19970902T101500"},
+			},
+			{
+				"every hour and a half for 4 occurrences",
+				"FREQ=MINUTELY;INTERVAL=90;COUNT=4", "19970902",
+				[]string{"19970902", "19970902T103000", "19970902T120000", "19970902T133000"},
+			},
+			{
+				"WKST=MO changes the result of a biweekly rule",
+				"FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=MO", "19970805",
+				[]string{"19970805", "19970810", "19970819", "19970824"},
+			},
+			{
+				"WKST=SU changes the result of a biweekly rule",
+				"FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=SU", "19970805",
+				[]string{"19970805", "19970817", "19970819", "19970831"},
+			},
+			{
+				"invalid dates are ignored",
+				"FREQ=MONTHLY;BYMONTHDAY=15,30;COUNT=5", "20070115",
+				[]string{"20070115", "20070130", "20070215", "20070315", "20070330"},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				// a rule with an end must not have more occurrences
+				n := len(tt.want)
+				if strings.Contains(tt.rule, "COUNT=") || strings.Contains(tt.rule, "UNTIL=") {
+					n++
+				}
+				assert.Equal(t, expand(t, tt.rule, tt.start, n), tt.want)
+			})
+		}
+	})
+
+	t.Run("RFC5545Until", func(t *testing.T) {
+		days := expand(t, "FREQ=DAILY;UNTIL=19971224T000000Z", "19970902", 200)
+		assert.Equal(t, len(days), 113)
+		assert.Equal(t, days[len(days)-1], "19971223")
+
+		yearly := expand(t, "FREQ=YEARLY;UNTIL=20000131T140000Z;BYMONTH=1;BYDAY=SU,MO,TU,WE,TH,FR,SA", "19980101", 200)
+		daily := expand(t, "RRULE:FREQ=DAILY;UNTIL=20000131T140000Z;BYMONTH=1", "19980101", 200)
+		assert.Equal(t, len(yearly), 93)
+		assert.Equal(t, yearly, daily)
+		assert.Equal(t, yearly[len(yearly)-1], "20000131")
+	})
+
+	t.Run("Expand", func(t *testing.T) {
+		// the local time is kept across a DST change
+		r := RRule{Freq: FreqDaily, Count: 3}
+		seq, err := r.Expand(NewDateTime(2024, 3, 9, 14, 0, 0), ny)
+		assert.NoError(t, err)
+		var got []DateTime
+		for dt := range seq {
+			got = append(got, dt)
+		}
+		assert.Equal(t, got, []DateTime{
+			NewDateTime(2024, 3, 9, 14, 0, 0),
+			NewDateTime(2024, 3, 10, 13, 0, 0),
+			NewDateTime(2024, 3, 11, 13, 0, 0),
+		})
+
+		// hourly rules step by elapsed time
+		assert.Equal(t, expand(t, "FREQ=HOURLY;INTERVAL=3;UNTIL=19970902T210000Z", "19970902", 10),
+			[]string{"19970902", "19970902T120000", "19970902T150000"})
+		assert.Equal(t, expand(t, "FREQ=HOURLY;COUNT=3", "20241103T000000", 10),
+			[]string{"20241103T000000", "20241103T010000", "20241103T010000"})
+		assert.Equal(t, expand(t, "FREQ=HOURLY;INTERVAL=12;BYDAY=MO;COUNT=3", "20240101T060000", 10),
+			[]string{"20240101T060000", "20240101T180000", "20240108T060000"})
+
+		// a start not matching the rule is not an occurrence
+		assert.Equal(t, expand(t, "FREQ=WEEKLY;BYDAY=FR;COUNT=2", "20240101", 10), []string{"20240105", "20240112"})
+
+		// a rule without occurrences ends after the year 9999
+		assert.Equal(t, expand(t, "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30", "20240101", 10), []string{})
+
+		_, err = RRule{}.Expand(NewDateTime(2024, 1, 1, 0, 0, 0), ny)
+		assert.ErrorContains(t, err, `frequency Frequency\(0\) is invalid`)
+	})
+
+	t.Run("ParseRRule", func(t *testing.T) {
+		r, err := ParseRRule("rrule:freq=monthly;interval=2;count=10;bymonth=1,6;bymonthday=1,-1;byday=2MO,-1fr,su;bysetpos=1,-1;wkst=su")
+		assert.NoError(t, err)
+		assert.Equal(t, r, RRule{
+			Freq:       FreqMonthly,
+			Interval:   2,
+			Count:      10,
+			ByMonth:    []time.Month{time.January, time.June},
+			ByMonthDay: []int{1, -1},
+			ByDay: []RRuleDay{
+				{N: 2, Weekday: NewWeekday(time.Monday)},
+				{N: -1, Weekday: NewWeekday(time.Friday)},
+				{Weekday: NewWeekday(time.Sunday)},
+			},
+			BySetPos:  []int{1, -1},
+			WeekStart: NewWeekday(time.Sunday),
+		})
+
+		r, err = ParseRRule("FREQ=DAILY;UNTIL=19971224T000000Z")
+		assert.NoError(t, err)
+		assert.Equal(t, r.Until, NewDateTime(1997, 12, 24, 0, 0, 0))
+		assert.Equal(t, r.WeekStart, NewWeekday(time.Monday))
+
+		errs := map[string]string{
+			"":                                          `invalid rrule part ""`,
+			"INTERVAL=2":                                "missing FREQ",
+			"FREQ=DAILY;FREQ=WEEKLY":                    "repeated",
+			"FREQ=FORTNIGHTLY":                          "invalid rrule part",
+			"FREQ=DAILY;INTERVAL=0":                     "invalid rrule part",
+			"FREQ=DAILY;COUNT=-1":                       "invalid rrule part",
+			"FREQ=DAILY;UNTIL=19971224":                 "invalid rrule part",
+			"FREQ=DAILY;UNTIL=19971224T000000":          "invalid rrule part",
+			"FREQ=DAILY;BYMONTH=1,,2":                   "invalid rrule part",
+			"FREQ=DAILY;BYDAY=XX":                       "invalid rrule part",
+			"FREQ=MONTHLY;BYDAY=0MO":                    "invalid rrule part",
+			"FREQ=MONTHLY;BYDAY=+MO":                    "invalid rrule part",
+			"FREQ=DAILY;WKST=MONDAY":                    "invalid rrule part",
+			"FREQ=DAILY;BYHOUR=9":                       `rrule part "BYHOUR" not supported`,
+			"FREQ=DAILY;COUNT=1;UNTIL=19971224T000000Z": "both COUNT and UNTIL",
+			"FREQ=DAILY;BYMONTH=13":                     "BYMONTH value 13 is out of range",
+			"FREQ=DAILY;BYMONTHDAY=32":                  "BYMONTHDAY value 32 is out of range",
+			"FREQ=WEEKLY;BYMONTHDAY=1":                  "can't be used with FREQ=WEEKLY",
+			"FREQ=WEEKLY;BYDAY=1MO":                     "BYDAY value 1MO can't be used with FREQ=WEEKLY",
+			"FREQ=YEARLY;BYDAY=54MO":                    "BYDAY value 54MO is out of range",
+			"FREQ=MONTHLY;BYSETPOS=1":                   "requires another BYxxx rule part",
+			"FREQ=MONTHLY;BYDAY=MO;BYSETPOS=0":          "BYSETPOS value 0 is out of range",
+			"FREQ=HOURLY;BYDAY=MO;BYSETPOS=1":           "can't be used with FREQ=HOURLY",
+		}
+		for s, want := range errs {
+			_, err := ParseRRule(s)
+			assert.ErrorContains(t, err, want)
+		}
+	})
+
+	t.Run("String", func(t *testing.T) {
+		rules := []string{
+			"FREQ=DAILY",
+			"FREQ=WEEKLY;INTERVAL=2;UNTIL=19971224T000000Z;BYDAY=MO,WE,FR;WKST=SU",
+			"FREQ=MONTHLY;COUNT=10;BYMONTH=1,6;BYMONTHDAY=1,-1;BYDAY=2MO,-1FR,SU;BYSETPOS=1,-1",
+			"FREQ=SECONDLY;INTERVAL=30",
+		}
+		for _, s := range rules {
+			r, err := ParseRRule(s)
+			assert.NoError(t, err)
+			assert.Equal(t, r.String(), s)
+		}
+		assert.Equal(t, RRule{Freq: FreqYearly}.String(), "FREQ=YEARLY;WKST=SU")
+		assert.Equal(t, Frequency(8).String(), "Frequency(8)")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		r, err := ParseRRule("FREQ=MONTHLY;BYDAY=-1FR")
+		assert.NoError(t, err)
+		out, err := json.Marshal(r)
+		assert.NoError(t, err)
+		assert.Equal(t, string(out), `"FREQ=MONTHLY;BYDAY=-1FR"`)
+
+		var got RRule
+		err = json.Unmarshal(out, &got)
+		assert.NoError(t, err)
+		assert.Equal(t, got, r)
+
+		type S struct {
+			Rule RRule `json:"rule"`
+		}
+		out, err = json.Marshal(S{})
+		assert.NoError(t, err)
+		assert.Equal(t, string(out), `{"rule":""}`)
+		var s S
+		err = json.Unmarshal(out, &s)
+		assert.NoError(t, err)
+		assert.Equal(t, s, S{})
+
+		err = json.Unmarshal([]byte(`"FREQ=MONTHLY;BYDAY=-1XX"`), &got)
+		assert.ErrorContains(t, err, "invalid rrule part")
+		err = json.Unmarshal([]byte(`1`), &got)
+		assert.Error(t, err)
+	})
+
+	t.Run("Value", func(t *testing.T) {
+		v, err := RRule{Freq: FreqDaily, Count: 2, WeekStart: NewWeekday(time.Monday)}.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, v, driver.Value("FREQ=DAILY;COUNT=2"))
+
+		v, err = RRule{}.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, v, driver.Value(""))
+	})
+
+	t.Run("Scan", func(t *testing.T) {
+		var r RRule
+		err := r.Scan([]byte("FREQ=DAILY;COUNT=2"))
+		assert.NoError(t, err)
+		assert.Equal(t, r, RRule{Freq: FreqDaily, Count: 2, WeekStart: NewWeekday(time.Monday)})
+
+		err = r.Scan("")
+		assert.NoError(t, err)
+		assert.Equal(t, r, RRule{})
+
+		err = r.Scan("FREQ=DAILY;COUNT=0")
+		assert.ErrorContains(t, err, "invalid rrule part")
+		err = r.Scan(nil)
+		assert.ErrorContains(t, err, "cannot be null")
+		err = r.Scan(int64(1))
+		assert.ErrorContains(t, err, "cannot be scanned from int64")
+	})
+}
@@ -1,11 +1,38 @@
 package timeapi
 
 import (
+	"cmp"
+	"database/sql/driver"
 	"fmt"
+	"math"
+	"math/big"
 	"strconv"
+	"strings"
 	"time"
 )
 
+// JSONFormat selects the textual form used when marshaling to JSON.
+type JSONFormat int
+
+const (
+	// FormatCompact is the compact form, such as "1y2mo3d" or "1h30m".
+	FormatCompact JSONFormat = iota
+
+	// FormatISO8601 is the ISO 8601 duration form,
+	// such as "P1Y2M3D" or "PT1H30M".
+	FormatISO8601
+)
+
+// IntervalJSONFormat is the form used by Interval.MarshalJSON.
+// Interval.UnmarshalJSON accepts both forms regardless of it.
+// It is meant to be set once, before any marshaling takes place.
+var IntervalJSONFormat = FormatCompact
+
+// DurationJSONFormat is the form used by Duration.MarshalJSON.
+// Duration.UnmarshalJSON accepts both forms regardless of it.
+// It is meant to be set once, before any marshaling takes place.
+var DurationJSONFormat = FormatCompact
+
 // Interval represents an interval (date and time) between two instants.
 type Interval struct {
 	year   int
@@ -46,6 +73,54 @@ func NewIntervalTime(hour, minute, second int) Interval {
 	}
 }
 
+// IntervalBetween returns the calendar difference between the dates
+// a and b in years, months and days, like the age function of Postgres.
+// The result is chosen so that a.AddInterval(IntervalBetween(a, b)) is b.
+// If b is before a, all components are negative.
+func IntervalBetween(a, b Date) Interval {
+	months := (b.year-a.year)*12 + int(b.month-a.month)
+	if !b.Before(a) {
+		if a.AddMonths(months, MonthClamp).After(b) {
+			months--
+		}
+	} else {
+		if a.AddMonths(months, MonthClamp).Before(b) {
+			months++
+		}
+	}
+	days := b.Sub(a.AddMonths(months, MonthClamp))
+	return NewIntervalDate(months/12, months%12, days)
+}
+
+// IntervalBetweenDateTime returns the calendar difference between a and b
+// in years, months, days, hours, minutes and seconds, like the age function
+// of Postgres. The result is chosen so that adding it back to a in UTC
+// with DateTime.AddInterval gives b. If b is before a, all components
+// are negative.
+func IntervalBetweenDateTime(a, b DateTime) Interval {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	da, db := Date{ay, am, ad}, Date{by, bm, bd}
+
+	ah, amin, as := a.Clock()
+	bh, bmin, bs := b.Clock()
+	secs := (bh-ah)*3600 + (bmin-amin)*60 + (bs - as)
+
+	// borrow a day, so that time and date parts have the same sign
+	switch {
+	case !b.Before(a) && secs < 0:
+		db = db.AddDays(-1)
+		secs += 24 * 3600
+	case b.Before(a) && secs > 0:
+		db = db.AddDays(1)
+		secs -= 24 * 3600
+	}
+
+	i := IntervalBetween(da, db)
+	i.hour, i.minute, i.second = secs/3600, secs%3600/60, secs%60
+	return i
+}
+
 func (i Interval) String() string {
 	// special case if all values are zero
 	if i.IsZero() {
@@ -74,7 +149,45 @@ func (i Interval) String() string {
 	return s
 }
 
+// ISOString returns the interval in the ISO 8601 duration format,
+// such as "P1Y2M3DT4H5M6S". Negative components carry their own sign.
+func (i Interval) ISOString() string {
+	// special case if all values are zero
+	if i.IsZero() {
+		return "PT0S"
+	}
+
+	s := "P"
+	if i.year != 0 {
+		s += strconv.Itoa(i.year) + "Y"
+	}
+	if i.month != 0 {
+		s += strconv.Itoa(i.month) + "M"
+	}
+	if i.day != 0 {
+		s += strconv.Itoa(i.day) + "D"
+	}
+	if i.hour == 0 && i.minute == 0 && i.second == 0 {
+		return s
+	}
+
+	s += "T"
+	if i.hour != 0 {
+		s += strconv.Itoa(i.hour) + "H"
+	}
+	if i.minute != 0 {
+		s += strconv.Itoa(i.minute) + "M"
+	}
+	if i.second != 0 {
+		s += strconv.Itoa(i.second) + "S"
+	}
+	return s
+}
+
 func (i Interval) MarshalJSON() ([]byte, error) {
+	if IntervalJSONFormat == FormatISO8601 {
+		return []byte(`"` + i.ISOString() + `"`), nil
+	}
 	return []byte(`"` + i.String() + `"`), nil
 }
 
@@ -84,7 +197,11 @@ func (i *Interval) UnmarshalJSON(b []byte) error {
 	}
 
 	b = b[1 : len(b)-1]
-	in, err := ParseInterval(string(b))
+	parse := ParseInterval
+	if isISODuration(string(b)) {
+		parse = ParseIntervalISO
+	}
+	in, err := parse(string(b))
 	if err != nil {
 		return NewErrJsonValue(err)
 	}
@@ -92,6 +209,71 @@ func (i *Interval) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// Value implements the driver.Valuer interface.
+// The interval is written in a form accepted by Postgres,
+// such as "1 year 2 mons 3 days 4 hours 5 mins 6 secs".
+func (i Interval) Value() (driver.Value, error) {
+	// special case if all values are zero
+	if i.IsZero() {
+		return "00:00:00", nil
+	}
+
+	units := []struct {
+		v    int
+		name string
+	}{
+		{i.year, "year"},
+		{i.month, "mon"},
+		{i.day, "day"},
+		{i.hour, "hour"},
+		{i.minute, "min"},
+		{i.second, "sec"},
+	}
+
+	var parts []string
+	for _, u := range units {
+		switch u.v {
+		case 0:
+		case 1, -1:
+			parts = append(parts, strconv.Itoa(u.v)+" "+u.name)
+		default:
+			parts = append(parts, strconv.Itoa(u.v)+" "+u.name+"s")
+		}
+	}
+	return strings.Join(parts, " "), nil
+}
+
+// Scan implements the sql.Scanner interface.
+// It accepts []byte and string values in the postgres
+// ("1 year 2 mons 3 days 04:05:06"), postgres_verbose
+// ("@ 1 year 2 mons 3 days 4 hours 5 mins 6 secs ago") and
+// iso_8601 ("P1Y2M3DT4H5M6S") interval styles of Postgres.
+// Fractional seconds are truncated.
+// Use sql.Null[Interval] for nullable columns.
+func (i *Interval) Scan(src any) error {
+	switch v := src.(type) {
+	case []byte:
+		return i.Scan(string(v))
+	case string:
+		var in Interval
+		var err error
+		if isISODuration(v) {
+			in, err = parseIntervalISO(v, true)
+		} else {
+			in, err = parsePostgresInterval(v)
+		}
+		if err != nil {
+			return NewErrSqlValue(err)
+		}
+		*i = in
+		return nil
+	case nil:
+		return NewErrSqlValue(fmt.Errorf("interval cannot be null"))
+	default:
+		return NewErrSqlValue(fmt.Errorf("interval cannot be scanned from %T", src))
+	}
+}
+
 // Is Zero reports whether i represents the zero interval.
 func (i Interval) IsZero() bool {
 	return i.year == 0 && i.month == 0 &&
@@ -109,19 +291,184 @@ func (i Interval) Time() (hour, min, sec int) {
 	return i.hour, i.minute, i.second
 }
 
+// DurationAt returns the exact elapsed time from anchor
+// to anchor.AddInterval(i, tz).
+func (i Interval) DurationAt(anchor DateTime, tz Timezone) Duration {
+	return anchor.AddInterval(i, tz).Sub(anchor)
+}
+
+// ApproxDuration returns the approximate duration of i, taking
+// a month as 30 days, a year as 365.25 days and a day as 24 hours.
+// It returns an error if the result overflows.
+func (i Interval) ApproxDuration() (Duration, error) {
+	secs := i.approxSeconds()
+	if !secs.IsInt64() || secs.Int64() > math.MaxInt || secs.Int64() <= math.MinInt {
+		return Duration{}, fmt.Errorf("timeapi: interval %s overflows duration", i)
+	}
+	return durationFromSeconds(int(secs.Int64())), nil
+}
+
+// Compare compares the approximate durations of i and j, as computed
+// by ApproxDuration, and returns -1 if i is shorter than j, 0 if they
+// are equally long and +1 if i is longer than j. For example,
+// 1mo compares equal to 30d and 1y compares longer than 12mo.
+func (i Interval) Compare(j Interval) int {
+	return i.approxSeconds().Cmp(j.approxSeconds())
+}
+
+// approxSeconds returns the approximate length of i in seconds,
+// as described in ApproxDuration.
+func (i Interval) approxSeconds() *big.Int {
+	components := []struct {
+		v    int
+		secs int64
+	}{
+		{i.year, 36525 * 24 * 3600 / 100}, // 365.25 days
+		{i.month, 30 * 24 * 3600},
+		{i.day, 24 * 3600},
+		{i.hour, 3600},
+		{i.minute, 60},
+		{i.second, 1},
+	}
+
+	total := new(big.Int)
+	for _, c := range components {
+		v := new(big.Int).Mul(big.NewInt(int64(c.v)), big.NewInt(c.secs))
+		total.Add(total, v)
+	}
+	return total
+}
+
+// Add returns the interval i+j, adding the components one by one.
+func (i Interval) Add(j Interval) Interval {
+	return Interval{
+		year:   i.year + j.year,
+		month:  i.month + j.month,
+		day:    i.day + j.day,
+		hour:   i.hour + j.hour,
+		minute: i.minute + j.minute,
+		second: i.second + j.second,
+	}
+}
+
+// Neg returns the interval -i.
+func (i Interval) Neg() Interval {
+	return i.Mul(-1)
+}
+
+// Mul returns the interval i*n, multiplying the components one by one.
+func (i Interval) Mul(n int) Interval {
+	return Interval{
+		year:   i.year * n,
+		month:  i.month * n,
+		day:    i.day * n,
+		hour:   i.hour * n,
+		minute: i.minute * n,
+		second: i.second * n,
+	}
+}
+
+// JustifyHours returns i with 24 hour periods carried into days,
+// like the justify_hours function of Postgres, so 27h becomes 1d3h.
+// Minutes and seconds are carried into hours, and days and time
+// are adjusted to have the same sign.
+func (i Interval) JustifyHours() Interval {
+	days, secs := justifyTime(i.day, i.timeSeconds())
+	return Interval{i.year, i.month, days, secs / 3600, secs % 3600 / 60, secs % 60}
+}
+
+// JustifyDays returns i with 30 day periods carried into months,
+// like the justify_days function of Postgres, so 35d becomes 1mo5d.
+// Months are carried into years, and years, months and days
+// are adjusted to have the same sign.
+func (i Interval) JustifyDays() Interval {
+	months, days := justifyDays(12*i.year+i.month, i.day)
+	return Interval{months / 12, months % 12, days, i.hour, i.minute, i.second}
+}
+
+// Justify returns i with 24 hour periods carried into days and 30 day
+// periods carried into months, like the justify_interval function
+// of Postgres. All components are adjusted to have the same sign.
+func (i Interval) Justify() Interval {
+	months := 12*i.year + i.month
+	days, secs := i.day, i.timeSeconds()
+
+	days += secs / (24 * 3600)
+	secs %= 24 * 3600
+	months += days / 30
+	days %= 30
+
+	if months > 0 && (days < 0 || (days == 0 && secs < 0)) {
+		days += 30
+		months--
+	} else if months < 0 && (days > 0 || (days == 0 && secs > 0)) {
+		days -= 30
+		months++
+	}
+	days, secs = justifyTime(days, secs)
+
+	return Interval{months / 12, months % 12, days, secs / 3600, secs % 3600 / 60, secs % 60}
+}
+
+// timeSeconds returns the time component of i in seconds.
+func (i Interval) timeSeconds() int {
+	return i.hour*3600 + i.minute*60 + i.second
+}
+
+// justifyTime carries whole days from secs into days
+// and makes both values have the same sign.
+func justifyTime(days, secs int) (int, int) {
+	days += secs / (24 * 3600)
+	secs %= 24 * 3600
+	if days > 0 && secs < 0 {
+		secs += 24 * 3600
+		days--
+	} else if days < 0 && secs > 0 {
+		secs -= 24 * 3600
+		days++
+	}
+	return days, secs
+}
+
+// justifyDays carries 30 day periods from days into months
+// and makes both values have the same sign.
+func justifyDays(months, days int) (int, int) {
+	months += days / 30
+	days %= 30
+	if months > 0 && days < 0 {
+		days += 30
+		months--
+	} else if months < 0 && days > 0 {
+		days -= 30
+		months++
+	}
+	return months, days
+}
+
 // Duration represents the duration between two instants.
+//
+// A duration has a single sign that applies to all of its components,
+// so -1h30m is ninety minutes before and never an hour before plus
+// thirty minutes after. The zero duration is never negative,
+// and the zero value of Duration is the zero duration.
 type Duration struct {
-	neg    int
+	neg    bool
 	hour   int
 	minute int
 	second int
 }
 
 // NewDuration returns a new Duration instance.
+// All non-zero components must have the same sign, which becomes
+// the sign of the duration, so NewDuration(-1, -30, 0) is -1h30m.
+// It panics if the components have mixed signs or one of them is math.MinInt.
 func NewDuration(hour, minute, second int) Duration {
-	neg := 1
-	if hour < 0 || minute < 0 || second < 0 {
-		neg = -1
+	if hour == math.MinInt || minute == math.MinInt || second == math.MinInt {
+		panic(fmt.Sprintf("duration %dh%dm%ds is out of range", hour, minute, second))
+	}
+	neg := hour < 0 || minute < 0 || second < 0
+	if neg && (hour > 0 || minute > 0 || second > 0) {
+		panic(fmt.Sprintf("duration %dh%dm%ds has mixed signs", hour, minute, second))
 	}
 	return Duration{
 		neg:    neg,
@@ -139,7 +486,7 @@ func (d Duration) String() string {
 	}
 
 	var s string
-	if d.neg == -1 {
+	if d.neg {
 		s += "-"
 	}
 	if d.hour != 0 {
@@ -159,29 +506,202 @@ func (d Duration) IsZero() bool {
 	return d.hour == 0 && d.minute == 0 && d.second == 0
 }
 
-// Hours returns the duration hours.
+// Sign returns -1 if d is negative, 0 if it is zero and 1 otherwise.
+func (d Duration) Sign() int {
+	switch {
+	case d.IsZero():
+		return 0
+	case d.neg:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// Hours returns the duration hours with the sign of d.
 func (d Duration) Hours() int {
-	return d.neg * d.hour
+	return d.sign() * d.hour
 }
 
-// Minutes returns the duration minutes.
+// Minutes returns the duration minutes with the sign of d.
 func (d Duration) Minutes() int {
-	return d.neg * d.minute
+	return d.sign() * d.minute
 }
 
-// Seconds returns the duration seconds.
+// Seconds returns the duration seconds with the sign of d.
 func (d Duration) Seconds() int {
-	return d.neg * d.second
+	return d.sign() * d.second
+}
+
+// sign returns -1 if d is negative and 1 otherwise.
+func (d Duration) sign() int {
+	if d.neg {
+		return -1
+	}
+	return 1
+}
+
+// Rounding defines how the sub-second part of a time.Duration
+// is handled when it is converted to a Duration.
+type Rounding int
+
+const (
+	// RoundTruncate drops the sub-second part, rounding toward zero.
+	RoundTruncate Rounding = iota
+
+	// RoundNearest rounds to the nearest second,
+	// rounding halfway values away from zero.
+	RoundNearest
+)
+
+// DurationOf returns the Duration of the standard go time.Duration d.
+// The sub-second part of d is handled according to r.
+func DurationOf(d time.Duration, r Rounding) Duration {
+	secs := int(d / time.Second)
+	if r == RoundNearest {
+		rem := d % time.Second
+		if rem >= time.Second/2 {
+			secs++
+		} else if rem <= -time.Second/2 {
+			secs--
+		}
+	}
+	return durationFromSeconds(secs)
+}
+
+// Add returns the duration d+u.
+// It returns an error if the result overflows.
+func (d Duration) Add(u Duration) (Duration, error) {
+	ds, ok1 := d.checkedSeconds()
+	us, ok2 := u.checkedSeconds()
+	secs, ok3 := addInt(ds, us)
+	if !ok1 || !ok2 || !ok3 || secs == math.MinInt {
+		return Duration{}, fmt.Errorf("timeapi: duration %s + %s overflows", d, u)
+	}
+	return durationFromSeconds(secs), nil
+}
+
+// Sub returns the duration d-u.
+// It returns an error if the result overflows.
+func (d Duration) Sub(u Duration) (Duration, error) {
+	ds, ok1 := d.checkedSeconds()
+	us, ok2 := u.checkedSeconds()
+	secs, ok3 := addInt(ds, -us)
+	if !ok1 || !ok2 || !ok3 || secs == math.MinInt {
+		return Duration{}, fmt.Errorf("timeapi: duration %s - %s overflows", d, u)
+	}
+	return durationFromSeconds(secs), nil
+}
+
+// Mul returns the duration d*n.
+// It returns an error if the result overflows.
+func (d Duration) Mul(n int) (Duration, error) {
+	ds, ok1 := d.checkedSeconds()
+	secs, ok2 := mulInt(ds, n)
+	if !ok1 || !ok2 || secs == math.MinInt {
+		return Duration{}, fmt.Errorf("timeapi: duration %s * %d overflows", d, n)
+	}
+	return durationFromSeconds(secs), nil
+}
+
+// Neg returns the duration -d.
+func (d Duration) Neg() Duration {
+	d.neg = !d.neg && !d.IsZero()
+	return d
+}
+
+// Abs returns the absolute value of d.
+func (d Duration) Abs() Duration {
+	d.neg = false
+	return d
+}
+
+// Normalize returns d with seconds carried into minutes and minutes
+// carried into hours, so that 90m becomes 1h30m.
+// It returns an error if the result overflows.
+func (d Duration) Normalize() (Duration, error) {
+	secs, ok := d.checkedSeconds()
+	if !ok {
+		return Duration{}, fmt.Errorf("timeapi: duration %s overflows", d)
+	}
+	return durationFromSeconds(secs), nil
+}
+
+// checkedSeconds returns the duration in seconds
+// and reports whether the computation did not overflow.
+func (d Duration) checkedSeconds() (int, bool) {
+	h, ok1 := mulInt(d.hour, 3600)
+	m, ok2 := mulInt(d.minute, 60)
+	secs, ok3 := addInt(h, m)
+	secs, ok4 := addInt(secs, d.second)
+	if !ok1 || !ok2 || !ok3 || !ok4 {
+		return 0, false
+	}
+	return d.sign() * secs, true
+}
+
+// totalSeconds returns the duration in seconds.
+func (d Duration) totalSeconds() int {
+	return d.sign() * (d.hour*3600 + d.minute*60 + d.second)
+}
+
+// durationFromSeconds returns the Duration of secs seconds
+// with minutes and seconds in the [0, 59] range.
+func durationFromSeconds(secs int) Duration {
+	neg := secs < 0
+	secs = abs(secs)
+	return Duration{
+		neg:    neg,
+		hour:   secs / 3600,
+		minute: secs % 3600 / 60,
+		second: secs % 60,
+	}
 }
 
 // GoDuration returns the standard go time.Duration instance.
+// The sign of d applies to all of its components. Durations out of
+// the time.Duration range, about 292 years, are saturated to the
+// minimum or maximum time.Duration.
 func (d Duration) GoDuration() time.Duration {
-	return time.Duration(d.neg)*time.Duration(d.hour)*time.Hour +
-		time.Duration(d.minute)*time.Minute +
-		time.Duration(d.second)*time.Second
+	secs, ok := d.checkedSeconds()
+	if !ok || int64(abs(secs)) > math.MaxInt64/int64(time.Second) {
+		if d.neg {
+			return math.MinInt64
+		}
+		return math.MaxInt64
+	}
+	return time.Duration(secs) * time.Second
+}
+
+// ISOString returns the duration in the ISO 8601 duration format,
+// such as "PT1H30M" or "-PT5S".
+func (d Duration) ISOString() string {
+	// special case if all values are zero
+	if d.hour == 0 && d.minute == 0 && d.second == 0 {
+		return "PT0S"
+	}
+
+	var s string
+	if d.neg {
+		s += "-"
+	}
+	s += "PT"
+	if d.hour != 0 {
+		s += strconv.Itoa(d.hour) + "H"
+	}
+	if d.minute != 0 {
+		s += strconv.Itoa(d.minute) + "M"
+	}
+	if d.second != 0 {
+		s += strconv.Itoa(d.second) + "S"
+	}
+	return s
 }
 
 func (d Duration) MarshalJSON() ([]byte, error) {
+	if DurationJSONFormat == FormatISO8601 {
+		return []byte(`"` + d.ISOString() + `"`), nil
+	}
 	return []byte(`"` + d.String() + `"`), nil
 }
 
@@ -192,7 +712,11 @@ func (d *Duration) UnmarshalJSON(b []byte) error {
 	}
 	b = b[1 : len(b)-1]
 
-	dur, err := ParseDuration(string(b))
+	parse := ParseDuration
+	if isISODuration(string(b)) {
+		parse = ParseDurationISO
+	}
+	dur, err := parse(string(b))
 	if err != nil {
 		return NewErrJsonValue(err)
 	}
@@ -267,6 +791,10 @@ var namesToWeekday = map[string]time.Weekday{
 	"SATURDAY":  time.Saturday,
 }
 
+// weekdayShortNames are the two-letter weekday names
+// of RFC 5545, indexed by time.Weekday.
+var weekdayShortNames = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
 // NewWeekday returns a new Weekday instance. It panics if the weekday is out of range.
 func NewWeekday(w time.Weekday) Weekday {
 	if w < time.Sunday || w > time.Saturday {
@@ -284,25 +812,162 @@ func (w Weekday) GoWeekday() time.Weekday {
 	return w.w
 }
 
+// WeekdayFromISONumber returns the weekday with the ISO 8601 number n,
+// from 1 for Monday to 7 for Sunday.
+func WeekdayFromISONumber(n int) (Weekday, error) {
+	if n < 1 || n > 7 {
+		return Weekday{}, fmt.Errorf("timeapi: iso weekday number %d is out of range", n)
+	}
+	return Weekday{w: time.Weekday(n % 7)}, nil
+}
+
+// ISONumber returns the ISO 8601 number of the weekday,
+// from 1 for Monday to 7 for Sunday.
+func (w Weekday) ISONumber() int {
+	if w.w == time.Sunday {
+		return 7
+	}
+	return int(w.w)
+}
+
+// Add returns the weekday n days after w. n may be negative.
+func (w Weekday) Add(n int) Weekday {
+	return Weekday{w: time.Weekday(floorMod(int(w.w)+n, 7))}
+}
+
+// Next returns the weekday after w.
+func (w Weekday) Next() Weekday {
+	return w.Add(1)
+}
+
+// Prev returns the weekday before w.
+func (w Weekday) Prev() Weekday {
+	return w.Add(-1)
+}
+
+// DaysUntil returns the number of days from w to the next u,
+// from 0 if u is w to 6.
+func (w Weekday) DaysUntil(u Weekday) int {
+	return floorMod(int(u.w)-int(w.w), 7)
+}
+
+// Compare compares the positions of w and u in a week starting on weekStart.
+// It returns -1 if w comes before u, 0 if they are the same day
+// and +1 if w comes after u. For example, Sunday comes before Monday
+// if the week starts on Sunday and after it if the week starts on Monday.
+func (w Weekday) Compare(u, weekStart Weekday) int {
+	return cmp.Compare(weekStart.DaysUntil(w), weekStart.DaysUntil(u))
+}
+
 func (w Weekday) MarshalJSON() ([]byte, error) {
 	return []byte(`"` + w.String() + `"`), nil
 }
 
+// UnmarshalJSON decodes the weekday with the policy WeekdayJSONPolicy.
+// Errors list the values allowed by the policy.
 func (w *Weekday) UnmarshalJSON(b []byte) error {
-	// strip quotes
-	if len(b) < 2 {
+	return w.unmarshalJSON(b, WeekdayJSONPolicy)
+}
+
+func (w *Weekday) unmarshalJSON(b []byte, p WeekdayPolicy) error {
+	if p == WeekdayStrict {
+		// strip quotes
+		if len(b) < 2 {
+			return NewErrJsonValue(fmt.Errorf("weekday %q is invalid", string(b)))
+		}
+		b = b[1 : len(b)-1]
+
+		weekday, ok := namesToWeekday[string(b)]
+		if !ok {
+			return NewErrJsonValue(fmt.Errorf("weekday invalid value %q, allowed values are %s", string(b), weekdayAllowedValues(p)))
+		}
+		w.w = weekday
+		return nil
+	}
+
+	s := string(b)
+	switch {
+	case len(b) >= 2 && b[0] == '"' && b[len(b)-1] == '"':
+		// strip quotes
+		s = s[1 : len(s)-1]
+	case p&WeekdayISONumbers == 0:
+		// only iso numbers may be unquoted
 		return NewErrJsonValue(fmt.Errorf("weekday %q is invalid", string(b)))
 	}
-	b = b[1 : len(b)-1]
 
-	weekday, ok := namesToWeekday[string(b)]
-	if !ok {
-		return NewErrJsonValue(fmt.Errorf("weekday invalid value %q", string(b)))
+	weekday, err := ParseWeekday(s, p)
+	if err != nil {
+		return NewErrJsonValue(err)
 	}
-	w.w = weekday
+	*w = weekday
 	return nil
 }
 
+// WeekdayPolicy selects the weekday forms accepted by ParseWeekday,
+// in addition to the full upper-case names, such as "MONDAY".
+// Policies can be combined with the | operator.
+type WeekdayPolicy uint8
+
+// WeekdayStrict accepts only the full upper-case names.
+const WeekdayStrict WeekdayPolicy = 0
+
+const (
+	// WeekdayCaseInsensitive accepts names in any case, such as "Monday".
+	WeekdayCaseInsensitive WeekdayPolicy = 1 << iota
+
+	// WeekdayAbbreviations accepts three-letter names, such as "MON",
+	// and the two-letter names of RFC 5545, such as "MO".
+	WeekdayAbbreviations
+
+	// WeekdayISONumbers accepts the ISO 8601 numbers, from 1 for Monday
+	// to 7 for Sunday. In JSON they may be given as numbers or strings.
+	WeekdayISONumbers
+)
+
+// WeekdayLenient accepts all of the forms above.
+const WeekdayLenient = WeekdayCaseInsensitive | WeekdayAbbreviations | WeekdayISONumbers
+
+// WeekdayJSONPolicy is the policy used by Weekday.UnmarshalJSON.
+// Weekday.MarshalJSON always writes the full upper-case name.
+// It is meant to be set once, before any unmarshaling takes place.
+// Use PolicyWeekday or LenientWeekday to set the policy of a single field.
+var WeekdayJSONPolicy = WeekdayStrict
+
+// WeekdayPolicySource provides the policy of a PolicyWeekday.
+// It is usually implemented by an empty struct type:
+//
+//	type caseInsensitive struct{}
+//
+//	func (caseInsensitive) WeekdayPolicy() timeapi.WeekdayPolicy {
+//		return timeapi.WeekdayCaseInsensitive
+//	}
+type WeekdayPolicySource interface {
+	WeekdayPolicy() WeekdayPolicy
+}
+
+// PolicyWeekday is a Weekday decoded from JSON with the policy
+// of P, regardless of WeekdayJSONPolicy. It is encoded as a Weekday.
+// For example, a field of type PolicyWeekday[caseInsensitive] accepts
+// "Monday" while other Weekday fields keep the global policy.
+type PolicyWeekday[P WeekdayPolicySource] struct {
+	Weekday
+}
+
+func (w *PolicyWeekday[P]) UnmarshalJSON(b []byte) error {
+	var p P
+	return w.Weekday.unmarshalJSON(b, p.WeekdayPolicy())
+}
+
+// LenientWeekday is a Weekday decoded from JSON with the WeekdayLenient
+// policy, regardless of WeekdayJSONPolicy. It is encoded as a Weekday.
+type LenientWeekday struct {
+	Weekday
+}
+
+func (w *LenientWeekday) UnmarshalJSON(b []byte) error {
+	return w.Weekday.unmarshalJSON(b, WeekdayLenient)
+}
+
 // time layout
 const (
 	timeLayout       = "15:04:05"
@@ -363,6 +1028,37 @@ func (t Time) Equal(u Time) bool {
 	return t.hour == u.hour && t.min == u.min && t.sec == u.sec
 }
 
+// Add returns the time t+d wrapped around midnight and the number
+// of days carried over, which is negative if the result is on a previous day.
+// For example, 20:00:00 + 8h30m is 04:30:00 with a day carry of 1.
+func (t Time) Add(d Duration) (Time, int) {
+	secs := t.seconds() + d.totalSeconds()
+	return timeFromSeconds(floorMod(secs, 24*3600)), floorDiv(secs, 24*3600)
+}
+
+// AddWrap returns the time t+d wrapped around midnight,
+// discarding the day carry reported by Add.
+func (t Time) AddWrap(d Duration) Time {
+	tm, _ := t.Add(d)
+	return tm
+}
+
+// Sub returns the duration t-u within the same day.
+// It is negative if t is before u.
+func (t Time) Sub(u Time) Duration {
+	return durationFromSeconds(t.seconds() - u.seconds())
+}
+
+// seconds returns the number of seconds since midnight.
+func (t Time) seconds() int {
+	return t.hour*3600 + t.min*60 + t.sec
+}
+
+// timeFromSeconds returns the Time secs seconds after midnight.
+func timeFromSeconds(secs int) Time {
+	return Time{secs / 3600, secs % 3600 / 60, secs % 60}
+}
+
 func (t Time) MarshalJSON() ([]byte, error) {
 	return []byte(`"` + t.String() + `"`), nil
 }
@@ -378,6 +1074,44 @@ func (t *Time) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// Value implements the driver.Valuer interface.
+// The time is written in the "15:04:05" format.
+func (t Time) Value() (driver.Value, error) {
+	return t.String(), nil
+}
+
+// Scan implements the sql.Scanner interface.
+// It accepts []byte and string values in the "15:04:05" format,
+// optionally followed by fractional seconds, which are truncated.
+// A time.Time value must have the 0000-01-01 date, as returned
+// by drivers for TIME columns. Use sql.Null[Time] for nullable columns.
+func (t *Time) Scan(src any) error {
+	var tm time.Time
+	switch v := src.(type) {
+	case time.Time:
+		if y, m, d := v.Date(); y != 0 || m != time.January || d != 1 {
+			return NewErrSqlValue(fmt.Errorf("time %s has a date component", v))
+		}
+		tm = v
+	case []byte:
+		return t.Scan(string(v))
+	case string:
+		var err error
+		if tm, err = time.Parse(timeLayout, v); err != nil {
+			return NewErrSqlValue(err)
+		}
+	case nil:
+		return NewErrSqlValue(fmt.Errorf("time cannot be null"))
+	default:
+		return NewErrSqlValue(fmt.Errorf("time cannot be scanned from %T", src))
+	}
+
+	t.hour = tm.Hour()
+	t.min = tm.Minute()
+	t.sec = tm.Second()
+	return nil
+}
+
 // date layout
 const (
 	dateLayout       = "2006-01-02"
@@ -410,6 +1144,11 @@ func (d Date) Date() (year int, month time.Month, day int) {
 	return d.year, d.month, d.day
 }
 
+// Weekday returns the day of the week of d.
+func (d Date) Weekday() Weekday {
+	return Weekday{w: time.Weekday(weekdayOf(daysFromCivil(d.year, d.month, d.day)))}
+}
+
 // Before reports whether the date d is before u.
 func (d Date) Before(u Date) bool {
 	return d.year < u.year ||
@@ -431,6 +1170,54 @@ func (d Date) Equal(u Date) bool {
 		d.day == u.day
 }
 
+// MonthPolicy defines how adding months to a date treats a day
+// that does not exist in the resulting month.
+type MonthPolicy int
+
+const (
+	// MonthClamp clamps the day to the last day of the resulting month,
+	// so Jan 31 + 1 month is Feb 28 (or Feb 29 in leap years).
+	MonthClamp MonthPolicy = iota
+
+	// MonthOverflow carries the extra days into the following month,
+	// like time.Time.AddDate, so Jan 31 + 1 month is Mar 3 (or Mar 2).
+	MonthOverflow
+)
+
+// AddDays returns the date d+n days.
+func (d Date) AddDays(n int) Date {
+	year, month, day := civilFromDays(daysFromCivil(d.year, d.month, d.day) + n)
+	return Date{year, month, day}
+}
+
+// AddMonths returns the date d+n months.
+// The policy p decides about days missing in the resulting month.
+func (d Date) AddMonths(n int, p MonthPolicy) Date {
+	year, month, day := addMonths(d.year, d.month, d.day, n, p)
+	return Date{year, month, day}
+}
+
+// AddYears returns the date d+n years.
+// The policy p decides about Feb 29 in non-leap years.
+func (d Date) AddYears(n int, p MonthPolicy) Date {
+	return d.AddMonths(12*n, p)
+}
+
+// AddInterval returns the date d+i. Years and months are added first,
+// clamping the day to the end of the month as Postgres does,
+// then days are added. It returns an error if i has a time component.
+func (d Date) AddInterval(i Interval) (Date, error) {
+	if i.hour != 0 || i.minute != 0 || i.second != 0 {
+		return d, fmt.Errorf("timeapi: interval %s has a time component", i)
+	}
+	return d.AddMonths(12*i.year+i.month, MonthClamp).AddDays(i.day), nil
+}
+
+// Sub returns the number of days d-u.
+func (d Date) Sub(u Date) int {
+	return daysFromCivil(d.year, d.month, d.day) - daysFromCivil(u.year, u.month, u.day)
+}
+
 func (d Date) MarshalJSON() ([]byte, error) {
 	return []byte(`"` + d.String() + `"`), nil
 }
@@ -447,6 +1234,110 @@ func (d *Date) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// Value implements the driver.Valuer interface.
+// The date is written in the "2006-01-02" format.
+func (d Date) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// Scan implements the sql.Scanner interface.
+// It accepts time.Time, []byte and string values. A time.Time value
+// must be at midnight with a zero UTC offset, so that no information
+// is lost. Use sql.Null[Date] for nullable columns.
+func (d *Date) Scan(src any) error {
+	var tm time.Time
+	switch v := src.(type) {
+	case time.Time:
+		if _, offset := v.Zone(); offset != 0 {
+			return NewErrSqlValue(fmt.Errorf("date %s is not in UTC", v))
+		}
+		if v.Hour() != 0 || v.Minute() != 0 || v.Second() != 0 || v.Nanosecond() != 0 {
+			return NewErrSqlValue(fmt.Errorf("date %s has a time component", v))
+		}
+		tm = v
+	case []byte:
+		return d.Scan(string(v))
+	case string:
+		var err error
+		if tm, err = time.Parse(dateLayout, v); err != nil {
+			return NewErrSqlValue(err)
+		}
+	case nil:
+		return NewErrSqlValue(fmt.Errorf("date cannot be null"))
+	default:
+		return NewErrSqlValue(fmt.Errorf("date cannot be scanned from %T", src))
+	}
+
+	d.year = tm.Year()
+	d.month = tm.Month()
+	d.day = tm.Day()
+	return nil
+}
+
+// daysIn returns the number of days in the month of the year.
+func daysIn(year int, month time.Month) int {
+	if month == time.February {
+		if year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+			return 29
+		}
+		return 28
+	}
+	return 31 - int(month-1)%7%2
+}
+
+// addMonths adds n months to the date and resolves
+// days missing in the resulting month with the policy p.
+func addMonths(year int, month time.Month, day, n int, p MonthPolicy) (int, time.Month, int) {
+	m := year*12 + int(month-1) + n
+	year, month = floorDiv(m, 12), time.Month(floorMod(m, 12)+1)
+	if last := daysIn(year, month); day > last {
+		if p == MonthClamp {
+			return year, month, last
+		}
+		return civilFromDays(daysFromCivil(year, month, last) + day - last)
+	}
+	return year, month, day
+}
+
+// daysFromCivil returns the number of days since 1970-01-01
+// in the proleptic Gregorian calendar.
+func daysFromCivil(year int, month time.Month, day int) int {
+	// http://howardhinnant.github.io/date_algorithms.html#days_from_civil
+	if month <= time.February {
+		year--
+	}
+	era := floorDiv(year, 400)
+	yoe := year - era*400
+	mp := (int(month) + 9) % 12
+	doy := (153*mp+2)/5 + day - 1
+	doe := yoe*365 + yoe/4 - yoe/100 + doy
+	return era*146097 + doe - 719468
+}
+
+// civilFromDays is the inverse of daysFromCivil.
+func civilFromDays(days int) (int, time.Month, int) {
+	// http://howardhinnant.github.io/date_algorithms.html#civil_from_days
+	days += 719468
+	era := floorDiv(days, 146097)
+	doe := days - era*146097
+	yoe := (doe - doe/1460 + doe/36524 - doe/146096) / 365
+	doy := doe - (365*yoe + yoe/4 - yoe/100)
+	mp := (5*doy + 2) / 153
+	day := doy - (153*mp+2)/5 + 1
+	month := time.Month((mp+2)%12 + 1)
+	year := yoe + era*400
+	if month <= time.February {
+		year++
+	}
+	return year, month, day
+}
+
+// weekdayOf returns the weekday of the day, as returned by daysFromCivil.
+func weekdayOf(days int) int {
+	// 1970-01-01 was a Thursday
+	return floorMod(days+int(time.Thursday), 7)
+}
+
 // dateTime layout
 const (
 	dateTimeLayout       = "2006-01-02T15:04:05Z"
@@ -501,6 +1392,34 @@ func (dt DateTime) Equal(u DateTime) bool {
 	return dt.t.Equal(u.t)
 }
 
+// Add returns the date and time dt+d.
+func (dt DateTime) Add(d Duration) DateTime {
+	return DateTime{time.Unix(dt.t.Unix()+int64(d.totalSeconds()), 0).UTC()}
+}
+
+// Sub returns the duration dt-u.
+func (dt DateTime) Sub(u DateTime) Duration {
+	return durationFromSeconds(int(dt.t.Unix() - u.t.Unix()))
+}
+
+// AddInterval returns the date and time dt+i. Years, months and days
+// are added to the wall clock time of dt in the timezone tz, clamping
+// the day to the end of the month as Postgres does, so one day added
+// across a DST change keeps the local hour. Hours, minutes and seconds
+// are then added as elapsed time. A wall clock time skipped or repeated
+// by a DST change is resolved as in time.Date.
+func (dt DateTime) AddInterval(i Interval, tz Timezone) DateTime {
+	loc := tz.GoLocation()
+	local := dt.t.In(loc)
+
+	year, month, day := addMonths(local.Year(), local.Month(), local.Day(), 12*i.year+i.month, MonthClamp)
+	year, month, day = civilFromDays(daysFromCivil(year, month, day) + i.day)
+	t := time.Date(year, month, day, local.Hour(), local.Minute(), local.Second(), 0, loc)
+
+	secs := i.hour*3600 + i.minute*60 + i.second
+	return DateTime{time.Unix(t.Unix()+int64(secs), 0).UTC()}
+}
+
 func (dt DateTime) MarshalJSON() ([]byte, error) {
 	return []byte(`"` + dt.String() + `"`), nil
 }
@@ -513,3 +1432,56 @@ func (dt *DateTime) UnmarshalJSON(b []byte) error {
 	dt.t = tm
 	return nil
 }
+
+// dateTimeScanLayouts are the text forms accepted by DateTime.Scan.
+// Layouts without a zone are interpreted as UTC.
+var dateTimeScanLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05Z07",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+}
+
+// parseScanDateTime parses s in one of the dateTimeScanLayouts.
+func parseScanDateTime(s string) (time.Time, error) {
+	for _, layout := range dateTimeScanLayouts {
+		if tm, err := time.Parse(layout, s); err == nil {
+			return tm, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("date and time %q is invalid", s)
+}
+
+// Value implements the driver.Valuer interface.
+// The date and time is written as a time.Time value in UTC.
+func (dt DateTime) Value() (driver.Value, error) {
+	return dt.t, nil
+}
+
+// Scan implements the sql.Scanner interface.
+// It accepts time.Time, []byte and string values, such as the ones
+// returned for timestamptz and timestamp columns. The scanned value
+// is converted to UTC and truncated to seconds.
+// Use sql.Null[DateTime] for nullable columns.
+func (dt *DateTime) Scan(src any) error {
+	var tm time.Time
+	switch v := src.(type) {
+	case time.Time:
+		tm = v
+	case []byte:
+		return dt.Scan(string(v))
+	case string:
+		var err error
+		if tm, err = parseScanDateTime(v); err != nil {
+			return NewErrSqlValue(err)
+		}
+	case nil:
+		return NewErrSqlValue(fmt.Errorf("date and time cannot be null"))
+	default:
+		return NewErrSqlValue(fmt.Errorf("date and time cannot be scanned from %T", src))
+	}
+
+	dt.t = tm.UTC().Truncate(time.Second)
+	return nil
+}
@@ -1,8 +1,14 @@
 package timeapi
 
 import (
+	"cmp"
+	"database/sql/driver"
 	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
 	"testing"
+	"testing/quick"
 	"time"
 
 	"github.com/krhubert/assert"
@@ -14,6 +20,101 @@ func TestInterval(t *testing.T) {
 		assert.Equal(t, NewInterval(1, 2, 3, 4, 5, 6).String(), "1y2mo3d4h5m6s")
 		assert.Equal(t, NewIntervalTime(4, 5, 6).String(), "4h5m6s")
 		assert.Equal(t, NewIntervalDate(4, 5, 6).String(), "4y5mo6d")
+		assert.Equal(t, NewIntervalDate(0, -1, 2).String(), "-1mo2d")
+		assert.Equal(t, NewInterval(-1, -2, -3, -4, -5, -6).String(), "-1y-2mo-3d-4h-5m-6s")
+	})
+
+	t.Run("Add", func(t *testing.T) {
+		i := NewInterval(1, 2, 3, 4, 5, 6).Add(NewInterval(1, -2, 0, 20, 55, -6))
+		assert.Equal(t, i, NewInterval(2, 0, 3, 24, 60, 0))
+	})
+
+	t.Run("Neg", func(t *testing.T) {
+		assert.Equal(t, NewInterval(1, -2, 3, 0, 5, 6).Neg(), NewInterval(-1, 2, -3, 0, -5, -6))
+		assert.Equal(t, NewInterval(0, 0, 0, 0, 0, 0).Neg(), NewInterval(0, 0, 0, 0, 0, 0))
+	})
+
+	t.Run("Mul", func(t *testing.T) {
+		assert.Equal(t, NewInterval(1, 2, 3, 4, 5, 6).Mul(2), NewInterval(2, 4, 6, 8, 10, 12))
+		assert.Equal(t, NewInterval(1, 2, 3, 4, 5, 6).Mul(-1), NewInterval(-1, -2, -3, -4, -5, -6))
+		assert.Equal(t, NewInterval(1, 2, 3, 4, 5, 6).Mul(0), NewInterval(0, 0, 0, 0, 0, 0))
+	})
+
+	t.Run("JustifyHours", func(t *testing.T) {
+		assert.Equal(t, NewIntervalTime(27, 0, 0).JustifyHours(), NewInterval(0, 0, 1, 3, 0, 0))
+		assert.Equal(t, NewIntervalTime(-27, 0, 0).JustifyHours(), NewInterval(0, 0, -1, -3, 0, 0))
+		assert.Equal(t, NewIntervalTime(0, 90, 61).JustifyHours(), NewIntervalTime(1, 31, 1))
+		assert.Equal(t, NewInterval(0, 0, 1, -1, 0, 0).JustifyHours(), NewIntervalTime(23, 0, 0))
+		assert.Equal(t, NewInterval(0, 0, -1, 1, 0, 0).JustifyHours(), NewIntervalTime(-23, 0, 0))
+		assert.Equal(t, NewInterval(0, 40, 0, 48, 0, 0).JustifyHours(), NewInterval(0, 40, 2, 0, 0, 0))
+	})
+
+	t.Run("JustifyDays", func(t *testing.T) {
+		assert.Equal(t, NewIntervalDate(0, 0, 35).JustifyDays(), NewIntervalDate(0, 1, 5))
+		assert.Equal(t, NewIntervalDate(0, 0, -35).JustifyDays(), NewIntervalDate(0, -1, -5))
+		assert.Equal(t, NewIntervalDate(0, 0, 400).JustifyDays(), NewIntervalDate(1, 1, 10))
+		assert.Equal(t, NewIntervalDate(0, 1, -1).JustifyDays(), NewIntervalDate(0, 0, 29))
+		assert.Equal(t, NewIntervalDate(1, -1, 0).JustifyDays(), NewIntervalDate(0, 11, 0))
+		assert.Equal(t, NewInterval(0, 0, 30, 48, 0, 0).JustifyDays(), NewInterval(0, 1, 0, 48, 0, 0))
+	})
+
+	t.Run("Justify", func(t *testing.T) {
+		assert.Equal(t, NewInterval(0, 1, 0, -1, 0, 0).Justify(), NewInterval(0, 0, 29, 23, 0, 0))
+		assert.Equal(t, NewInterval(0, -1, 0, 1, 0, 0).Justify(), NewInterval(0, 0, -29, -23, 0, 0))
+		assert.Equal(t, NewInterval(0, 0, 29, 24, 0, 0).Justify(), NewInterval(0, 1, 0, 0, 0, 0))
+		assert.Equal(t, NewInterval(0, 11, 29, 47, 59, 60).Justify(), NewInterval(1, 0, 1, 0, 0, 0))
+		assert.Equal(t, NewInterval(0, 1, 1, -25, 0, 0).Justify(), NewInterval(0, 0, 29, 23, 0, 0))
+		assert.Equal(t, NewInterval(0, 0, 0, 0, 0, 0).Justify(), NewInterval(0, 0, 0, 0, 0, 0))
+	})
+
+	t.Run("DurationAt", func(t *testing.T) {
+		utc := NewTimezone(*time.UTC)
+		loc, err := time.LoadLocation("America/New_York")
+		assert.NoError(t, err)
+		ny := NewTimezone(*loc)
+
+		anchor := NewDateTime(2021, 2, 1, 0, 0, 0)
+		assert.Equal(t, NewIntervalDate(0, 1, 0).DurationAt(anchor, utc), NewDuration(28*24, 0, 0))
+		assert.Equal(t, NewIntervalDate(0, -1, 0).DurationAt(anchor, utc), NewDuration(-31*24, 0, 0))
+		assert.Equal(t, NewIntervalDate(1, 0, 0).DurationAt(anchor, utc), NewDuration(365*24, 0, 0))
+		assert.Equal(t, NewIntervalTime(1, 2, 3).DurationAt(anchor, utc), NewDuration(1, 2, 3))
+
+		// 2021-03-14 is the start of DST in New York
+		anchor = NewDateTime(2021, 3, 13, 17, 0, 0)
+		assert.Equal(t, NewIntervalDate(0, 0, 1).DurationAt(anchor, ny), NewDuration(23, 0, 0))
+		assert.Equal(t, NewIntervalDate(0, 0, 1).DurationAt(anchor, utc), NewDuration(24, 0, 0))
+	})
+
+	t.Run("ApproxDuration", func(t *testing.T) {
+		tests := []struct {
+			i    Interval
+			want Duration
+		}{
+			{NewInterval(0, 0, 0, 0, 0, 0), NewDuration(0, 0, 0)},
+			{NewIntervalDate(0, 0, 7), NewDuration(7*24, 0, 0)},
+			{NewIntervalDate(0, 1, 0), NewDuration(30*24, 0, 0)},
+			{NewIntervalDate(1, 0, 0), NewDuration(365*24+6, 0, 0)},
+			{NewInterval(0, 0, 1, -1, 0, 0), NewDuration(23, 0, 0)},
+			{NewInterval(0, 0, -1, 0, 0, 1), NewDuration(-23, -59, -59)},
+		}
+		for _, tt := range tests {
+			d, err := tt.i.ApproxDuration()
+			assert.NoError(t, err)
+			assert.Equal(t, d, tt.want)
+		}
+
+		_, err := NewIntervalDate(math.MaxInt, 0, 0).ApproxDuration()
+		assert.ErrorContains(t, err, "overflows")
+	})
+
+	t.Run("Compare", func(t *testing.T) {
+		assert.Equal(t, NewIntervalDate(0, 0, 7).Compare(NewIntervalTime(7*24, 0, 0)), 0)
+		assert.Equal(t, NewIntervalDate(0, 1, 0).Compare(NewIntervalDate(0, 0, 30)), 0)
+		assert.Equal(t, NewIntervalDate(1, 0, 0).Compare(NewIntervalDate(0, 12, 0)), 1)
+		assert.Equal(t, NewIntervalDate(0, 0, 6).Compare(NewIntervalDate(0, 0, 7)), -1)
+		assert.Equal(t, NewIntervalTime(0, 0, -1).Compare(NewInterval(0, 0, 0, 0, 0, 0)), -1)
+		assert.Equal(t, NewIntervalDate(math.MaxInt, 0, 0).Compare(NewIntervalDate(math.MaxInt, 0, 1)), -1)
+		assert.Equal(t, NewIntervalDate(math.MinInt, 0, 0).Compare(NewIntervalDate(math.MaxInt, 0, 0)), -1)
 	})
 
 	t.Run("IsZero", func(t *testing.T) {
@@ -64,6 +165,27 @@ func TestInterval(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, i, NewInterval(1, 2, 3, 4, 5, 6))
 
+		err = json.Unmarshal([]byte(`"+1mo-1d"`), &i)
+		assert.NoError(t, err)
+		assert.Equal(t, i, NewIntervalDate(0, 1, -1))
+
+		err = json.Unmarshal([]byte(`"-1mo2d"`), &i)
+		assert.NoError(t, err)
+		assert.Equal(t, i, NewIntervalDate(0, -1, 2))
+
+		err = json.Unmarshal([]byte(`"-1y-2mo-3d-4h-5m-6s"`), &i)
+		assert.NoError(t, err)
+		assert.Equal(t, i, NewInterval(-1, -2, -3, -4, -5, -6))
+
+		err = json.Unmarshal([]byte(`"-"`), &i)
+		assert.ErrorContains(t, err, "invalid interval")
+
+		err = json.Unmarshal([]byte(`"--1d"`), &i)
+		assert.ErrorContains(t, err, "invalid interval")
+
+		err = json.Unmarshal([]byte(`"1d-"`), &i)
+		assert.ErrorContains(t, err, "invalid interval")
+
 		err = json.Unmarshal([]byte(`1`), &i)
 		assert.ErrorContains(t, err, "is invalid")
 
@@ -85,9 +207,278 @@ func TestInterval(t *testing.T) {
 		err = json.Unmarshal([]byte(`"1"`), &i)
 		assert.ErrorContains(t, err, "missing unit")
 	})
+
+	t.Run("IntervalBetween", func(t *testing.T) {
+		tests := []struct {
+			a, b Date
+			want Interval
+		}{
+			{NewDate(2021, 1, 1), NewDate(2021, 1, 1), NewIntervalDate(0, 0, 0)},
+			{NewDate(2021, 1, 1), NewDate(2023, 4, 3), NewIntervalDate(2, 3, 2)},
+			{NewDate(2021, 1, 31), NewDate(2021, 2, 28), NewIntervalDate(0, 1, 0)},
+			{NewDate(2021, 1, 31), NewDate(2021, 3, 1), NewIntervalDate(0, 1, 1)},
+			{NewDate(2020, 2, 29), NewDate(2021, 2, 28), NewIntervalDate(1, 0, 0)},
+			{NewDate(2021, 1, 15), NewDate(2021, 2, 14), NewIntervalDate(0, 0, 30)},
+			{NewDate(2023, 4, 3), NewDate(2021, 1, 1), NewIntervalDate(-2, -3, -2)},
+			{NewDate(2021, 3, 1), NewDate(2021, 1, 31), NewIntervalDate(0, -1, -1)},
+		}
+		for _, tt := range tests {
+			i := IntervalBetween(tt.a, tt.b)
+			assert.Equal(t, i, tt.want)
+
+			d, err := tt.a.AddInterval(i)
+			assert.NoError(t, err)
+			assert.Equal(t, d, tt.b)
+		}
+
+		start := NewDate(2019, 12, 25)
+		for i := 0; i < 500; i += 3 {
+			for j := 0; j < 500; j += 7 {
+				a, b := start.AddDays(i), start.AddDays(j)
+				d, err := a.AddInterval(IntervalBetween(a, b))
+				assert.NoError(t, err)
+				assert.Equal(t, d, b)
+			}
+		}
+	})
+
+	t.Run("IntervalBetweenDateTime", func(t *testing.T) {
+		tests := []struct {
+			a, b DateTime
+			want Interval
+		}{
+			{NewDateTime(2021, 1, 1, 0, 0, 0), NewDateTime(2021, 1, 1, 0, 0, 0), NewInterval(0, 0, 0, 0, 0, 0)},
+			{NewDateTime(2021, 1, 1, 10, 0, 0), NewDateTime(2022, 3, 2, 12, 30, 5), NewInterval(1, 2, 1, 2, 30, 5)},
+			{NewDateTime(2021, 1, 1, 10, 0, 0), NewDateTime(2021, 1, 2, 9, 0, 0), NewInterval(0, 0, 0, 23, 0, 0)},
+			{NewDateTime(2021, 1, 31, 12, 0, 0), NewDateTime(2021, 3, 1, 11, 0, 0), NewInterval(0, 1, 0, 23, 0, 0)},
+			{NewDateTime(2021, 1, 2, 9, 0, 0), NewDateTime(2021, 1, 1, 10, 0, 0), NewInterval(0, 0, 0, -23, 0, 0)},
+			{NewDateTime(2022, 3, 2, 12, 30, 5), NewDateTime(2021, 1, 1, 10, 0, 0), NewInterval(-1, -2, -1, -2, -30, -5)},
+		}
+		for _, tt := range tests {
+			i := IntervalBetweenDateTime(tt.a, tt.b)
+			assert.Equal(t, i, tt.want)
+			assert.Equal(t, tt.a.AddInterval(i, NewTimezone(*time.UTC)), tt.b)
+		}
+	})
+
+	t.Run("ParseInterval", func(t *testing.T) {
+		tests := []struct {
+			s    string
+			want Interval
+		}{
+			{"0", NewInterval(0, 0, 0, 0, 0, 0)},
+			{"+1mo-1d", NewIntervalDate(0, 1, -1)},
+			{"-1mo", NewIntervalDate(0, -1, 0)},
+			{"-1mo2d", NewIntervalDate(0, -1, 2)},
+			{"+1y+2mo+3d+4h+5m+6s", NewInterval(1, 2, 3, 4, 5, 6)},
+			{"-0d", NewInterval(0, 0, 0, 0, 0, 0)},
+			{strconv.Itoa(math.MinInt) + "y" + strconv.Itoa(math.MaxInt) + "s", NewInterval(math.MinInt, 0, 0, 0, 0, math.MaxInt)},
+		}
+		for _, tt := range tests {
+			i, err := ParseInterval(tt.s)
+			assert.NoError(t, err)
+			assert.Equal(t, i, tt.want)
+		}
+
+		for _, s := range []string{"+", "-", "+-1d", "1d+", "1d-", "-y", "99999999999999999999y", "-99999999999999999999y"} {
+			_, err := ParseInterval(s)
+			assert.ErrorContains(t, err, "invalid interval")
+		}
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		roundTrip := func(i Interval) bool {
+			p1, err1 := ParseInterval(i.String())
+			p2, err2 := ParseIntervalISO(i.ISOString())
+
+			var p3 Interval
+			b, err3 := json.Marshal(i)
+			err4 := json.Unmarshal(b, &p3)
+
+			var p4 Interval
+			v, err5 := i.Value()
+			err6 := p4.Scan(v)
+
+			return errors.Join(err1, err2, err3, err4, err5, err6) == nil &&
+				p1 == i && p2 == i && p3 == i && p4 == i
+		}
+
+		f := func(year, month, day, hour, minute, second int) bool {
+			return roundTrip(NewInterval(year, month, day, hour, minute, second))
+		}
+		assert.NoError(t, quick.Check(f, nil))
+
+		g := func(year, month, day, hour, minute, second int8) bool {
+			return roundTrip(NewInterval(int(year), int(month), int(day), int(hour), int(minute), int(second)))
+		}
+		assert.NoError(t, quick.Check(g, nil))
+
+		for _, n := range []int{0, 1, -1, math.MaxInt, math.MinInt} {
+			assert.True(t, roundTrip(NewInterval(n, -n, n, -n, n, -n)))
+		}
+	})
+
+	t.Run("ISOString", func(t *testing.T) {
+		assert.Equal(t, NewInterval(0, 0, 0, 0, 0, 0).ISOString(), "PT0S")
+		assert.Equal(t, NewInterval(1, 2, 3, 4, 5, 6).ISOString(), "P1Y2M3DT4H5M6S")
+		assert.Equal(t, NewIntervalDate(1, 0, 3).ISOString(), "P1Y3D")
+		assert.Equal(t, NewIntervalTime(0, 30, 0).ISOString(), "PT30M")
+		assert.Equal(t, NewInterval(0, 1, -1, 0, 0, -5).ISOString(), "P1M-1DT-5S")
+	})
+
+	t.Run("ParseIntervalISO", func(t *testing.T) {
+		tests := []struct {
+			s    string
+			want Interval
+		}{
+			{"PT0S", NewInterval(0, 0, 0, 0, 0, 0)},
+			{"P0D", NewInterval(0, 0, 0, 0, 0, 0)},
+			{"P1Y2M3DT4H5M6S", NewInterval(1, 2, 3, 4, 5, 6)},
+			{"P1M", NewInterval(0, 1, 0, 0, 0, 0)},
+			{"PT1M", NewInterval(0, 0, 0, 0, 1, 0)},
+			{"P2W", NewInterval(0, 0, 14, 0, 0, 0)},
+			{"P1W2D", NewInterval(0, 0, 9, 0, 0, 0)},
+			{"-P1Y2DT3H", NewInterval(-1, 0, -2, -3, 0, 0)},
+			{"+P1Y", NewInterval(1, 0, 0, 0, 0, 0)},
+			{"P1M-1D", NewInterval(0, 1, -1, 0, 0, 0)},
+			{"-P1M-1D", NewInterval(0, -1, 1, 0, 0, 0)},
+		}
+		for _, tt := range tests {
+			i, err := ParseIntervalISO(tt.s)
+			assert.NoError(t, err)
+			assert.Equal(t, i, tt.want)
+		}
+
+		for _, s := range []string{"", "P", "PT", "1Y", "P1YT", "P1", "PT1S1M"} {
+			_, err := ParseIntervalISO(s)
+			assert.Error(t, err)
+		}
+
+		_, err := ParseIntervalISO("PT1.5S")
+		assert.ErrorContains(t, err, "fractional value")
+
+		_, err = ParseIntervalISO("P1X")
+		assert.ErrorContains(t, err, "unknown unit \"X\"")
+
+		_, err = ParseIntervalISO("PT1H")
+		assert.NoError(t, err)
+
+		_, err = ParseIntervalISO("P1H")
+		assert.ErrorContains(t, err, "unknown unit \"H\"")
+
+		_, err = ParseIntervalISO("P1Y1Y")
+		assert.ErrorContains(t, err, "unit \"Y\" repeated")
+
+		_, err = ParseIntervalISO("P1D1W")
+		assert.ErrorContains(t, err, "unit \"W\" must be in the order")
+
+		_, err = ParseIntervalISO("P1D1M")
+		assert.ErrorContains(t, err, "unit \"M\" must be in the order")
+	})
+
+	t.Run("MarshalJSONISO8601", func(t *testing.T) {
+		IntervalJSONFormat = FormatISO8601
+		defer func() { IntervalJSONFormat = FormatCompact }()
+
+		out, err := json.Marshal(NewInterval(1, 2, 3, 4, 5, 6))
+		assert.NoError(t, err)
+		assert.Equal(t, string(out), `"P1Y2M3DT4H5M6S"`)
+
+		out, err = json.Marshal(NewInterval(0, 0, 0, 0, 0, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, string(out), `"PT0S"`)
+	})
+
+	t.Run("UnmarshalJSONISO8601", func(t *testing.T) {
+		var i Interval
+		err := json.Unmarshal([]byte(`"P1Y2M3DT4H5M6S"`), &i)
+		assert.NoError(t, err)
+		assert.Equal(t, i, NewInterval(1, 2, 3, 4, 5, 6))
+
+		err = json.Unmarshal([]byte(`"P2W"`), &i)
+		assert.NoError(t, err)
+		assert.Equal(t, i, NewIntervalDate(0, 0, 14))
+
+		err = json.Unmarshal([]byte(`"P1S"`), &i)
+		assert.ErrorContains(t, err, "unknown unit")
+	})
+
+	t.Run("Value", func(t *testing.T) {
+		tests := []struct {
+			i    Interval
+			want string
+		}{
+			{NewInterval(0, 0, 0, 0, 0, 0), "00:00:00"},
+			{NewInterval(1, 2, 3, 4, 5, 6), "1 year 2 mons 3 days 4 hours 5 mins 6 secs"},
+			{NewInterval(2, 1, 1, 1, 1, 1), "2 years 1 mon 1 day 1 hour 1 min 1 sec"},
+			{NewInterval(-1, 0, 3, 0, -5, 0), "-1 year 3 days -5 mins"},
+		}
+		for _, tt := range tests {
+			v, err := tt.i.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, v, driver.Value(tt.want))
+		}
+	})
+
+	t.Run("Scan", func(t *testing.T) {
+		tests := []struct {
+			src  any
+			want Interval
+		}{
+			// postgres
+			{"00:00:00", NewInterval(0, 0, 0, 0, 0, 0)},
+			{"1 year 2 mons 3 days 04:05:06", NewInterval(1, 2, 3, 4, 5, 6)},
+			{"-1 years -2 mons +3 days -04:05:06", NewInterval(-1, -2, 3, -4, -5, -6)},
+			{"1 day", NewInterval(0, 0, 1, 0, 0, 0)},
+			{"100:00:00.5", NewInterval(0, 0, 0, 100, 0, 0)},
+			{[]byte("2 years 1 mon"), NewInterval(2, 1, 0, 0, 0, 0)},
+			// postgres_verbose
+			{"@ 0", NewInterval(0, 0, 0, 0, 0, 0)},
+			{"@ 1 year 2 mons 3 days 4 hours 5 mins 6 secs", NewInterval(1, 2, 3, 4, 5, 6)},
+			{"@ 1 year 2 mons -3 days 4 hours 5 mins 6.5 secs ago", NewInterval(-1, -2, 3, -4, -5, -6)},
+			// iso_8601
+			{"PT0S", NewInterval(0, 0, 0, 0, 0, 0)},
+			{"P1Y2M3DT4H5M6S", NewInterval(1, 2, 3, 4, 5, 6)},
+			{"P-1Y-2M3DT-4H-5M-6.999999S", NewInterval(-1, -2, 3, -4, -5, -6)},
+			{"PT5M", NewInterval(0, 0, 0, 0, 5, 0)},
+			{"P1M", NewInterval(0, 1, 0, 0, 0, 0)},
+		}
+		for _, tt := range tests {
+			var i Interval
+			err := i.Scan(tt.src)
+			assert.NoError(t, err)
+			assert.Equal(t, i, tt.want)
+		}
+
+		for _, src := range []string{
+			"", "@", "1", "1 fortnight", "1 day 2 days", "1:2", "01:02:03 04:05:06",
+			"1.5 days", "P", "PT", "P1H", "PT1D", "P1D1Y", "P1Y1Y", "P1.5Y", "P1YT",
+		} {
+			var i Interval
+			err := i.Scan(src)
+			assert.Error(t, err)
+		}
+
+		var i Interval
+		err := i.Scan(nil)
+		assert.ErrorContains(t, err, "cannot be null")
+
+		err = i.Scan(int64(1))
+		assert.ErrorContains(t, err, "cannot be scanned from int64")
+	})
 }
 
 func TestDuration(t *testing.T) {
+	t.Run("NewDuration", func(t *testing.T) {
+		assert.Panic(t, func() { NewDuration(1, -30, 0) })
+		assert.Panic(t, func() { NewDuration(-1, 30, 0) })
+		assert.Panic(t, func() { NewDuration(0, 1, -1) })
+		assert.Panic(t, func() { NewDuration(math.MinInt, 0, 0) })
+		assert.NotPanic(t, func() { NewDuration(-1, 0, -1) })
+		assert.Equal(t, NewDuration(0, 0, 0), Duration{})
+		assert.Equal(t, NewDuration(-0, 0, 0), Duration{})
+	})
+
 	t.Run("String", func(t *testing.T) {
 		assert.Equal(t, NewDuration(0, 0, 0).String(), "0h0m0s")
 		assert.Equal(t, NewDuration(0, 0, 1).String(), "1s")
@@ -122,9 +513,162 @@ func TestDuration(t *testing.T) {
 		assert.Equal(t, NewDuration(1, 2, 61).Seconds(), 61)
 	})
 
+	t.Run("Sign", func(t *testing.T) {
+		assert.Equal(t, Duration{}.Sign(), 0)
+		assert.Equal(t, NewDuration(0, 0, 0).Sign(), 0)
+		assert.Equal(t, NewDuration(0, 0, 1).Sign(), 1)
+		assert.Equal(t, NewDuration(-1, 0, 0).Sign(), -1)
+		assert.Equal(t, NewDuration(-1, -30, 0).Minutes(), -30)
+	})
+
 	t.Run("GoDuration", func(t *testing.T) {
+		assert.Equal(t, Duration{}.GoDuration(), 0)
 		assert.Equal(t, NewDuration(0, 0, 0).GoDuration(), 0)
 		assert.Equal(t, NewDuration(1, 2, 3).GoDuration(), time.Hour+2*time.Minute+3*time.Second)
+		assert.Equal(t, NewDuration(-1, -30, 0).GoDuration(), -90*time.Minute)
+		assert.Equal(t, NewDuration(0, 0, -1).GoDuration(), -time.Second)
+		assert.Equal(t, NewDuration(math.MaxInt/3600, 0, 0).GoDuration(), time.Duration(math.MaxInt64))
+		assert.Equal(t, NewDuration(-math.MaxInt, 0, 0).GoDuration(), time.Duration(math.MinInt64))
+	})
+
+	t.Run("GoDurationRoundTrip", func(t *testing.T) {
+		maxSeconds := int64(math.MaxInt64 / time.Second)
+
+		// time.Duration -> Duration -> time.Duration
+		f := func(secs int64) bool {
+			gd := time.Duration(secs%maxSeconds) * time.Second
+			d := DurationOf(gd, RoundTruncate)
+			return d.GoDuration() == gd && d.Sign() == cmp.Compare(gd, 0)
+		}
+		assert.NoError(t, quick.Check(f, nil))
+
+		// Duration -> time.Duration -> Duration
+		g := func(hour, min, sec uint16, neg bool) bool {
+			sign := 1
+			if neg {
+				sign = -1
+			}
+			d := NewDuration(sign*int(hour), sign*int(min), sign*int(sec))
+			n, err := d.Normalize()
+			return err == nil &&
+				DurationOf(d.GoDuration(), RoundTruncate) == n &&
+				d.GoDuration() == time.Duration(sign)*(time.Duration(hour)*time.Hour+
+					time.Duration(min)*time.Minute+time.Duration(sec)*time.Second)
+		}
+		assert.NoError(t, quick.Check(g, nil))
+
+		// Duration -> string -> Duration
+		h := func(hour, min, sec uint16, neg bool) bool {
+			sign := 1
+			if neg {
+				sign = -1
+			}
+			d := NewDuration(sign*int(hour), sign*int(min), sign*int(sec))
+			p1, err1 := ParseDuration(d.String())
+			p2, err2 := ParseDurationISO(d.ISOString())
+			return err1 == nil && err2 == nil && p1 == d && p2 == d
+		}
+		assert.NoError(t, quick.Check(h, nil))
+	})
+
+	t.Run("DurationOf", func(t *testing.T) {
+		tests := []struct {
+			d    time.Duration
+			r    Rounding
+			want Duration
+		}{
+			{0, RoundTruncate, NewDuration(0, 0, 0)},
+			{90 * time.Minute, RoundTruncate, NewDuration(1, 30, 0)},
+			{-90 * time.Minute, RoundTruncate, NewDuration(-1, -30, 0)},
+			{1500 * time.Millisecond, RoundTruncate, NewDuration(0, 0, 1)},
+			{1500 * time.Millisecond, RoundNearest, NewDuration(0, 0, 2)},
+			{1499 * time.Millisecond, RoundNearest, NewDuration(0, 0, 1)},
+			{-1500 * time.Millisecond, RoundTruncate, NewDuration(0, 0, -1)},
+			{-1500 * time.Millisecond, RoundNearest, NewDuration(0, 0, -2)},
+			{-499 * time.Millisecond, RoundNearest, NewDuration(0, 0, 0)},
+		}
+		for _, tt := range tests {
+			assert.Equal(t, DurationOf(tt.d, tt.r), tt.want)
+		}
+	})
+
+	t.Run("Add", func(t *testing.T) {
+		d, err := NewDuration(1, 30, 0).Add(NewDuration(0, 45, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, d, NewDuration(2, 15, 0))
+
+		d, err = NewDuration(1, 0, 0).Add(NewDuration(0, -30, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, d, NewDuration(0, 30, 0))
+
+		d, err = NewDuration(0, 30, 0).Add(NewDuration(-1, 0, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, d, NewDuration(0, -30, 0))
+
+		_, err = NewDuration(math.MaxInt/3600, 0, 0).Add(NewDuration(1, 0, 0))
+		assert.ErrorContains(t, err, "overflows")
+
+		_, err = NewDuration(math.MaxInt, 0, 0).Add(NewDuration(0, 0, 0))
+		assert.ErrorContains(t, err, "overflows")
+	})
+
+	t.Run("Sub", func(t *testing.T) {
+		d, err := NewDuration(1, 30, 0).Sub(NewDuration(0, 45, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, d, NewDuration(0, 45, 0))
+
+		d, err = NewDuration(0, 0, 0).Sub(NewDuration(0, 45, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, d, NewDuration(0, -45, 0))
+
+		_, err = NewDuration(-math.MaxInt/3600, 0, 0).Sub(NewDuration(1, 0, 0))
+		assert.ErrorContains(t, err, "overflows")
+	})
+
+	t.Run("Mul", func(t *testing.T) {
+		d, err := NewDuration(1, 30, 0).Mul(3)
+		assert.NoError(t, err)
+		assert.Equal(t, d, NewDuration(4, 30, 0))
+
+		d, err = NewDuration(1, 30, 0).Mul(-2)
+		assert.NoError(t, err)
+		assert.Equal(t, d, NewDuration(-3, 0, 0))
+
+		d, err = NewDuration(1, 30, 0).Mul(0)
+		assert.NoError(t, err)
+		assert.Equal(t, d, NewDuration(0, 0, 0))
+
+		_, err = NewDuration(1, 0, 0).Mul(math.MaxInt / 1000)
+		assert.ErrorContains(t, err, "overflows")
+	})
+
+	t.Run("Neg", func(t *testing.T) {
+		assert.Equal(t, NewDuration(1, 2, 3).Neg(), NewDuration(-1, -2, -3))
+		assert.Equal(t, NewDuration(-1, -2, -3).Neg(), NewDuration(1, 2, 3))
+		assert.Equal(t, NewDuration(0, 0, 0).Neg(), NewDuration(0, 0, 0))
+	})
+
+	t.Run("Abs", func(t *testing.T) {
+		assert.Equal(t, NewDuration(1, 2, 3).Abs(), NewDuration(1, 2, 3))
+		assert.Equal(t, NewDuration(-1, -2, -3).Abs(), NewDuration(1, 2, 3))
+		assert.Equal(t, NewDuration(0, 0, 0).Abs(), NewDuration(0, 0, 0))
+	})
+
+	t.Run("Normalize", func(t *testing.T) {
+		d, err := NewDuration(0, 90, 0).Normalize()
+		assert.NoError(t, err)
+		assert.Equal(t, d, NewDuration(1, 30, 0))
+
+		d, err = NewDuration(1, 59, 61).Normalize()
+		assert.NoError(t, err)
+		assert.Equal(t, d, NewDuration(2, 0, 1))
+
+		d, err = NewDuration(0, -90, -3600).Normalize()
+		assert.NoError(t, err)
+		assert.Equal(t, d, NewDuration(-2, -30, 0))
+
+		_, err = NewDuration(math.MaxInt, 60, 0).Normalize()
+		assert.ErrorContains(t, err, "overflows")
 	})
 
 	t.Run("MarshalJSON", func(t *testing.T) {
@@ -143,7 +687,7 @@ func TestDuration(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, string(out), `"1h2m3s"`)
 
-		d = NewDuration(-1, 2, 3)
+		d = NewDuration(-1, -2, -3)
 		out, err = json.Marshal(d)
 		assert.NoError(t, err)
 		assert.Equal(t, string(out), `"-1h2m3s"`)
@@ -159,13 +703,25 @@ func TestDuration(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, d, NewDuration(0, 0, 0))
 
+		err = json.Unmarshal([]byte(`"-0"`), &d)
+		assert.NoError(t, err)
+		assert.Equal(t, d, NewDuration(0, 0, 0))
+
+		err = json.Unmarshal([]byte(`"-0h0m0s"`), &d)
+		assert.NoError(t, err)
+		assert.Equal(t, d, NewDuration(0, 0, 0))
+
+		err = json.Unmarshal([]byte(`"-PT0S"`), &d)
+		assert.NoError(t, err)
+		assert.Equal(t, d, NewDuration(0, 0, 0))
+
 		err = json.Unmarshal([]byte(`"1h2m3s"`), &d)
 		assert.NoError(t, err)
 		assert.Equal(t, d, NewDuration(1, 2, 3))
 
 		err = json.Unmarshal([]byte(`"-1h2m3s"`), &d)
 		assert.NoError(t, err)
-		assert.Equal(t, d, NewDuration(-1, 2, 3))
+		assert.Equal(t, d, NewDuration(-1, -2, -3))
 
 		err = json.Unmarshal([]byte(`"2h59m59s"`), &d)
 		assert.NoError(t, err)
@@ -207,6 +763,86 @@ func TestDuration(t *testing.T) {
 		err = json.Unmarshal([]byte(`0`), &d)
 		assert.ErrorContains(t, err, "is invalid")
 	})
+
+	t.Run("ISOString", func(t *testing.T) {
+		assert.Equal(t, NewDuration(0, 0, 0).ISOString(), "PT0S")
+		assert.Equal(t, NewDuration(0, 0, 1).ISOString(), "PT1S")
+		assert.Equal(t, NewDuration(1, 30, 0).ISOString(), "PT1H30M")
+		assert.Equal(t, NewDuration(1, 2, 3).ISOString(), "PT1H2M3S")
+		assert.Equal(t, NewDuration(0, 0, -5).ISOString(), "-PT5S")
+	})
+
+	t.Run("ParseDurationISO", func(t *testing.T) {
+		tests := []struct {
+			s    string
+			want Duration
+		}{
+			{"PT0S", NewDuration(0, 0, 0)},
+			{"PT1H30M", NewDuration(1, 30, 0)},
+			{"PT1H2M3S", NewDuration(1, 2, 3)},
+			{"PT90M", NewDuration(0, 90, 0)},
+			{"-PT5S", NewDuration(0, 0, -5)},
+			{"+PT5S", NewDuration(0, 0, 5)},
+		}
+		for _, tt := range tests {
+			d, err := ParseDurationISO(tt.s)
+			assert.NoError(t, err)
+			assert.Equal(t, d, tt.want)
+		}
+
+		for _, s := range []string{"", "P", "PT", "-", "T1H", "PT1", "PT-1S", "PT1.5S", "PTH"} {
+			_, err := ParseDurationISO(s)
+			assert.Error(t, err)
+		}
+
+		_, err := ParseDurationISO("P1D")
+		assert.ErrorContains(t, err, "unit \"D\" not allowed")
+
+		_, err = ParseDurationISO("P1Y")
+		assert.ErrorContains(t, err, "unit \"Y\" not allowed")
+
+		_, err = ParseDurationISO("P1DT1H")
+		assert.ErrorContains(t, err, "unit \"D\" not allowed")
+
+		_, err = ParseDurationISO("PT1D")
+		assert.ErrorContains(t, err, "unknown unit \"D\"")
+
+		_, err = ParseDurationISO("PT1H1H")
+		assert.ErrorContains(t, err, "unit \"H\" repeated")
+
+		_, err = ParseDurationISO("PT1S1M")
+		assert.ErrorContains(t, err, "unit \"M\" must be in the order")
+
+		_, err = ParseDurationISO("PT9999999999H")
+		assert.ErrorContains(t, err, "invalid duration")
+	})
+
+	t.Run("MarshalJSONISO8601", func(t *testing.T) {
+		DurationJSONFormat = FormatISO8601
+		defer func() { DurationJSONFormat = FormatCompact }()
+
+		out, err := json.Marshal(NewDuration(1, 30, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, string(out), `"PT1H30M"`)
+
+		out, err = json.Marshal(NewDuration(0, 0, -5))
+		assert.NoError(t, err)
+		assert.Equal(t, string(out), `"-PT5S"`)
+	})
+
+	t.Run("UnmarshalJSONISO8601", func(t *testing.T) {
+		var d Duration
+		err := json.Unmarshal([]byte(`"PT1H30M"`), &d)
+		assert.NoError(t, err)
+		assert.Equal(t, d, NewDuration(1, 30, 0))
+
+		err = json.Unmarshal([]byte(`"-PT5S"`), &d)
+		assert.NoError(t, err)
+		assert.Equal(t, d, NewDuration(0, 0, -5))
+
+		err = json.Unmarshal([]byte(`"P1D"`), &d)
+		assert.ErrorContains(t, err, "not allowed")
+	})
 }
 
 func TestTimezone(t *testing.T) {
@@ -285,6 +921,55 @@ func TestWeekday(t *testing.T) {
 		assert.Equal(t, NewWeekday(time.Saturday).GoWeekday(), time.Saturday)
 	})
 
+	t.Run("ISONumber", func(t *testing.T) {
+		assert.Equal(t, NewWeekday(time.Monday).ISONumber(), 1)
+		assert.Equal(t, NewWeekday(time.Saturday).ISONumber(), 6)
+		assert.Equal(t, NewWeekday(time.Sunday).ISONumber(), 7)
+
+		for n := 1; n <= 7; n++ {
+			w, err := WeekdayFromISONumber(n)
+			assert.NoError(t, err)
+			assert.Equal(t, w.ISONumber(), n)
+		}
+		w, err := WeekdayFromISONumber(7)
+		assert.NoError(t, err)
+		assert.Equal(t, w, NewWeekday(time.Sunday))
+
+		_, err = WeekdayFromISONumber(0)
+		assert.ErrorContains(t, err, "out of range")
+		_, err = WeekdayFromISONumber(8)
+		assert.ErrorContains(t, err, "out of range")
+	})
+
+	t.Run("Add", func(t *testing.T) {
+		assert.Equal(t, NewWeekday(time.Saturday).Next(), NewWeekday(time.Sunday))
+		assert.Equal(t, NewWeekday(time.Sunday).Prev(), NewWeekday(time.Saturday))
+		assert.Equal(t, NewWeekday(time.Monday).Add(0), NewWeekday(time.Monday))
+		assert.Equal(t, NewWeekday(time.Monday).Add(9), NewWeekday(time.Wednesday))
+		assert.Equal(t, NewWeekday(time.Monday).Add(-8), NewWeekday(time.Sunday))
+		assert.Equal(t, NewWeekday(time.Monday).Add(-14), NewWeekday(time.Monday))
+	})
+
+	t.Run("DaysUntil", func(t *testing.T) {
+		assert.Equal(t, NewWeekday(time.Monday).DaysUntil(NewWeekday(time.Monday)), 0)
+		assert.Equal(t, NewWeekday(time.Monday).DaysUntil(NewWeekday(time.Friday)), 4)
+		assert.Equal(t, NewWeekday(time.Friday).DaysUntil(NewWeekday(time.Monday)), 3)
+		assert.Equal(t, NewWeekday(time.Sunday).DaysUntil(NewWeekday(time.Saturday)), 6)
+	})
+
+	t.Run("Compare", func(t *testing.T) {
+		sun, mon := NewWeekday(time.Sunday), NewWeekday(time.Monday)
+		sat := NewWeekday(time.Saturday)
+
+		assert.Equal(t, sun.Compare(mon, mon), 1)
+		assert.Equal(t, sun.Compare(mon, sun), -1)
+		assert.Equal(t, mon.Compare(mon, sun), 0)
+		assert.Equal(t, sat.Compare(sun, mon), -1)
+		assert.Equal(t, sat.Compare(sun, sun), 1)
+		assert.Equal(t, sun.Compare(mon, sat), -1)
+		assert.Equal(t, sat.Compare(mon, sat), -1)
+	})
+
 	t.Run("MarshalJSON", func(t *testing.T) {
 		tests := []struct {
 			weekday time.Weekday
@@ -339,6 +1024,119 @@ func TestWeekday(t *testing.T) {
 
 		err = json.Unmarshal([]byte(`0`), &wd)
 		assert.Error(t, err)
+
+		err = json.Unmarshal([]byte(`"Monday"`), &wd)
+		assert.ErrorContains(t, err, `^timeapi: weekday invalid value "Monday", allowed values are MONDAY, TUESDAY, WEDNESDAY, THURSDAY, FRIDAY, SATURDAY, SUNDAY$`)
+	})
+
+	t.Run("UnmarshalJSONPolicy", func(t *testing.T) {
+		WeekdayJSONPolicy = WeekdayCaseInsensitive | WeekdayISONumbers
+		defer func() { WeekdayJSONPolicy = WeekdayStrict }()
+
+		var wd Weekday
+		err := json.Unmarshal([]byte(`"friday"`), &wd)
+		assert.NoError(t, err)
+		assert.Equal(t, wd, NewWeekday(time.Friday))
+
+		err = json.Unmarshal([]byte(`7`), &wd)
+		assert.NoError(t, err)
+		assert.Equal(t, wd, NewWeekday(time.Sunday))
+
+		err = json.Unmarshal([]byte(`"Fri"`), &wd)
+		assert.ErrorContains(t, err, "in any case and 1 \\(MONDAY\\) to 7 \\(SUNDAY\\)")
+	})
+
+	t.Run("ParseWeekday", func(t *testing.T) {
+		tests := []struct {
+			s    string
+			p    WeekdayPolicy
+			want time.Weekday
+		}{
+			{"MONDAY", WeekdayStrict, time.Monday},
+			{"SUNDAY", WeekdayLenient, time.Sunday},
+			{"Monday", WeekdayCaseInsensitive, time.Monday},
+			{"tuesday", WeekdayCaseInsensitive, time.Tuesday},
+			{"WED", WeekdayAbbreviations, time.Wednesday},
+			{"TH", WeekdayAbbreviations, time.Thursday},
+			{"sat", WeekdayCaseInsensitive | WeekdayAbbreviations, time.Saturday},
+			{"Su", WeekdayLenient, time.Sunday},
+			{"1", WeekdayISONumbers, time.Monday},
+			{"7", WeekdayISONumbers, time.Sunday},
+		}
+		for _, tt := range tests {
+			wd, err := ParseWeekday(tt.s, tt.p)
+			assert.NoError(t, err)
+			assert.Equal(t, wd, NewWeekday(tt.want))
+		}
+
+		errs := []struct {
+			s string
+			p WeekdayPolicy
+		}{
+			{"Monday", WeekdayStrict},
+			{"MON", WeekdayStrict},
+			{"1", WeekdayStrict},
+			{"mon", WeekdayAbbreviations},
+			{"MONDAYS", WeekdayLenient},
+			{"M", WeekdayLenient},
+			{"0", WeekdayLenient},
+			{"8", WeekdayLenient},
+			{"+1", WeekdayLenient},
+			{"", WeekdayLenient},
+		}
+		for _, tt := range errs {
+			_, err := ParseWeekday(tt.s, tt.p)
+			assert.ErrorContains(t, err, "invalid weekday")
+		}
+
+		_, err := ParseWeekday("x", WeekdayAbbreviations)
+		assert.ErrorContains(t, err, "SUNDAY, MON, TUE, WED, THU, FRI, SAT, SUN, MO, TU, WE, TH, FR, SA, SU$")
+	})
+
+	t.Run("PolicyWeekday", func(t *testing.T) {
+		assert.Equal(t, WeekdayCaseInsensitive, WeekdayPolicy(1))
+		assert.Equal(t, WeekdayAbbreviations, WeekdayPolicy(2))
+		assert.Equal(t, WeekdayISONumbers, WeekdayPolicy(4))
+
+		var v struct {
+			Day    PolicyWeekday[testCaseInsensitive] `json:"day"`
+			Strict Weekday                            `json:"strict"`
+		}
+		err := json.Unmarshal([]byte(`{"day":"Monday","strict":"FRIDAY"}`), &v)
+		assert.NoError(t, err)
+		assert.Equal(t, v.Day.Weekday, NewWeekday(time.Monday))
+		assert.Equal(t, v.Strict, NewWeekday(time.Friday))
+
+		out, err := json.Marshal(v)
+		assert.NoError(t, err)
+		assert.Equal(t, string(out), `{"day":"MONDAY","strict":"FRIDAY"}`)
+
+		err = json.Unmarshal([]byte(`{"day":"Mon"}`), &v)
+		assert.ErrorContains(t, err, `"Mon"`)
+		err = json.Unmarshal([]byte(`{"day":1}`), &v)
+		assert.Error(t, err)
+		err = json.Unmarshal([]byte(`{"strict":"Friday"}`), &v)
+		assert.Error(t, err)
+	})
+
+	t.Run("LenientWeekday", func(t *testing.T) {
+		var v struct {
+			Day LenientWeekday `json:"day"`
+		}
+		for _, in := range []string{`"MONDAY"`, `"Monday"`, `"mon"`, `"Mo"`, `1`, `"1"`} {
+			err := json.Unmarshal([]byte(`{"day":`+in+`}`), &v)
+			assert.NoError(t, err)
+			assert.Equal(t, v.Day.Weekday, NewWeekday(time.Monday))
+		}
+
+		out, err := json.Marshal(v)
+		assert.NoError(t, err)
+		assert.Equal(t, string(out), `{"day":"MONDAY"}`)
+
+		err = json.Unmarshal([]byte(`{"day":"Mondays"}`), &v)
+		assert.ErrorContains(t, err, `invalid weekday "Mondays"`)
+		err = json.Unmarshal([]byte(`{"day":true}`), &v)
+		assert.Error(t, err)
 	})
 }
 
@@ -399,6 +1197,36 @@ func TestTime(t *testing.T) {
 		assert.False(t, NewTime(0, 0, 0).Equal(NewTime(1, 0, 0)))
 	})
 
+	t.Run("Add", func(t *testing.T) {
+		tests := []struct {
+			t     Time
+			d     Duration
+			want  Time
+			carry int
+		}{
+			{NewTime(8, 0, 0), NewDuration(8, 30, 0), NewTime(16, 30, 0), 0},
+			{NewTime(20, 0, 0), NewDuration(8, 30, 0), NewTime(4, 30, 0), 1},
+			{NewTime(23, 59, 59), NewDuration(0, 0, 1), NewTime(0, 0, 0), 1},
+			{NewTime(0, 0, 0), NewDuration(48, 0, 0), NewTime(0, 0, 0), 2},
+			{NewTime(1, 0, 0), NewDuration(-1, 0, 0), NewTime(0, 0, 0), 0},
+			{NewTime(1, 0, 0), NewDuration(0, 0, -3601), NewTime(23, 59, 59), -1},
+			{NewTime(1, 0, 0), NewDuration(-49, 0, 0), NewTime(0, 0, 0), -2},
+		}
+		for _, tt := range tests {
+			tm, carry := tt.t.Add(tt.d)
+			assert.Equal(t, tm, tt.want)
+			assert.Equal(t, carry, tt.carry)
+			assert.Equal(t, tt.t.AddWrap(tt.d), tt.want)
+		}
+	})
+
+	t.Run("Sub", func(t *testing.T) {
+		assert.Equal(t, NewTime(16, 30, 0).Sub(NewTime(8, 0, 0)), NewDuration(8, 30, 0))
+		assert.Equal(t, NewTime(8, 0, 0).Sub(NewTime(16, 30, 0)), NewDuration(-8, -30, 0))
+		assert.Equal(t, NewTime(8, 0, 0).Sub(NewTime(8, 0, 0)), NewDuration(0, 0, 0))
+		assert.Equal(t, NewTime(23, 59, 59).Sub(NewTime(0, 0, 0)), NewDuration(23, 59, 59))
+	})
+
 	t.Run("MarshalJSON", func(t *testing.T) {
 		tm := NewTime(0, 0, 0)
 		out, err := json.Marshal(tm)
@@ -426,6 +1254,45 @@ func TestTime(t *testing.T) {
 		err = json.Unmarshal([]byte(`"01:02:03:04"`), &tm)
 		assert.Error(t, err)
 	})
+
+	t.Run("Value", func(t *testing.T) {
+		v, err := NewTime(1, 2, 3).Value()
+		assert.NoError(t, err)
+		assert.Equal(t, v, driver.Value("01:02:03"))
+	})
+
+	t.Run("Scan", func(t *testing.T) {
+		var tm Time
+		err := tm.Scan("15:04:05")
+		assert.NoError(t, err)
+		assert.Equal(t, tm, NewTime(15, 4, 5))
+
+		err = tm.Scan([]byte("15:04:06.999999"))
+		assert.NoError(t, err)
+		assert.Equal(t, tm, NewTime(15, 4, 6))
+
+		err = tm.Scan(time.Date(0, 1, 1, 1, 2, 3, 500, time.UTC))
+		assert.NoError(t, err)
+		assert.Equal(t, tm, NewTime(1, 2, 3))
+
+		err = tm.Scan(time.Date(2021, 1, 1, 1, 2, 3, 0, time.UTC))
+		assert.ErrorContains(t, err, "has a date component")
+
+		err = tm.Scan("24:00:00")
+		assert.ErrorContains(t, err, "hour out of range")
+
+		err = tm.Scan("838:59:59")
+		assert.Error(t, err)
+
+		err = tm.Scan("15:04")
+		assert.Error(t, err)
+
+		err = tm.Scan(nil)
+		assert.ErrorContains(t, err, "cannot be null")
+
+		err = tm.Scan(int64(1))
+		assert.ErrorContains(t, err, "cannot be scanned from int64")
+	})
 }
 
 func TestDate(t *testing.T) {
@@ -477,6 +1344,72 @@ func TestDate(t *testing.T) {
 		assert.False(t, NewDate(2021, 1, 1).Equal(NewDate(2022, 1, 1)))
 	})
 
+	t.Run("AddDays", func(t *testing.T) {
+		assert.Equal(t, NewDate(2021, 1, 1).AddDays(0), NewDate(2021, 1, 1))
+		assert.Equal(t, NewDate(2021, 1, 31).AddDays(1), NewDate(2021, 2, 1))
+		assert.Equal(t, NewDate(2020, 2, 28).AddDays(1), NewDate(2020, 2, 29))
+		assert.Equal(t, NewDate(2021, 12, 31).AddDays(1), NewDate(2022, 1, 1))
+		assert.Equal(t, NewDate(2021, 1, 1).AddDays(-1), NewDate(2020, 12, 31))
+		assert.Equal(t, NewDate(2000, 1, 1).AddDays(366), NewDate(2001, 1, 1))
+
+		start := time.Date(1600, 1, 1, 0, 0, 0, 0, time.UTC)
+		for i := 0; i < 1000*366; i += 17 {
+			tm := start.AddDate(0, 0, i)
+			want := NewDate(tm.Year(), tm.Month(), tm.Day())
+			assert.Equal(t, NewDate(1600, 1, 1).AddDays(i), want)
+			assert.Equal(t, want.Sub(NewDate(1600, 1, 1)), i)
+		}
+	})
+
+	t.Run("AddMonths", func(t *testing.T) {
+		assert.Equal(t, NewDate(2021, 1, 15).AddMonths(1, MonthClamp), NewDate(2021, 2, 15))
+		assert.Equal(t, NewDate(2021, 1, 31).AddMonths(1, MonthClamp), NewDate(2021, 2, 28))
+		assert.Equal(t, NewDate(2020, 1, 31).AddMonths(1, MonthClamp), NewDate(2020, 2, 29))
+		assert.Equal(t, NewDate(2021, 1, 31).AddMonths(1, MonthOverflow), NewDate(2021, 3, 3))
+		assert.Equal(t, NewDate(2020, 1, 31).AddMonths(1, MonthOverflow), NewDate(2020, 3, 2))
+		assert.Equal(t, NewDate(2021, 3, 31).AddMonths(-1, MonthClamp), NewDate(2021, 2, 28))
+		assert.Equal(t, NewDate(2021, 1, 31).AddMonths(-2, MonthClamp), NewDate(2020, 11, 30))
+		assert.Equal(t, NewDate(2021, 11, 30).AddMonths(14, MonthClamp), NewDate(2023, 1, 30))
+		assert.Equal(t, NewDate(2021, 1, 31).AddMonths(-13, MonthOverflow), NewDate(2019, 12, 31))
+	})
+
+	t.Run("AddYears", func(t *testing.T) {
+		assert.Equal(t, NewDate(2021, 3, 1).AddYears(1, MonthClamp), NewDate(2022, 3, 1))
+		assert.Equal(t, NewDate(2020, 2, 29).AddYears(1, MonthClamp), NewDate(2021, 2, 28))
+		assert.Equal(t, NewDate(2020, 2, 29).AddYears(1, MonthOverflow), NewDate(2021, 3, 1))
+		assert.Equal(t, NewDate(2020, 2, 29).AddYears(-4, MonthClamp), NewDate(2016, 2, 29))
+	})
+
+	t.Run("AddInterval", func(t *testing.T) {
+		d, err := NewDate(2021, 1, 31).AddInterval(NewIntervalDate(1, 1, 1))
+		assert.NoError(t, err)
+		assert.Equal(t, d, NewDate(2022, 3, 1))
+
+		d, err = NewDate(2021, 3, 1).AddInterval(NewIntervalDate(0, -1, -1))
+		assert.NoError(t, err)
+		assert.Equal(t, d, NewDate(2021, 1, 31))
+
+		_, err = NewDate(2021, 1, 31).AddInterval(NewInterval(0, 0, 1, 1, 0, 0))
+		assert.ErrorContains(t, err, "has a time component")
+	})
+
+	t.Run("Sub", func(t *testing.T) {
+		assert.Equal(t, NewDate(2021, 1, 1).Sub(NewDate(2021, 1, 1)), 0)
+		assert.Equal(t, NewDate(2021, 1, 2).Sub(NewDate(2021, 1, 1)), 1)
+		assert.Equal(t, NewDate(2021, 1, 1).Sub(NewDate(2021, 1, 2)), -1)
+		assert.Equal(t, NewDate(2021, 3, 1).Sub(NewDate(2020, 3, 1)), 365)
+		assert.Equal(t, NewDate(2020, 3, 1).Sub(NewDate(2019, 3, 1)), 366)
+		assert.Equal(t, NewDate(1970, 1, 1).Sub(NewDate(1, 1, 1)), 719162)
+	})
+
+	t.Run("Weekday", func(t *testing.T) {
+		assert.Equal(t, NewDate(1970, 1, 1).Weekday(), NewWeekday(time.Thursday))
+		assert.Equal(t, NewDate(2024, 2, 29).Weekday(), NewWeekday(time.Thursday))
+		assert.Equal(t, NewDate(2024, 3, 3).Weekday(), NewWeekday(time.Sunday))
+		assert.Equal(t, NewDate(1, 1, 1).Weekday(), NewWeekday(time.Monday))
+		assert.Equal(t, NewDate(9999, 12, 31).Weekday(), NewWeekday(time.Friday))
+	})
+
 	t.Run("MarshalJSON", func(t *testing.T) {
 		d := NewDate(2021, 1, 1)
 		out, err := json.Marshal(d)
@@ -495,6 +1428,46 @@ func TestDate(t *testing.T) {
 		err = json.Unmarshal([]byte(`"2021-01-01-01"`), &d)
 		assert.Error(t, err)
 	})
+
+	t.Run("Value", func(t *testing.T) {
+		v, err := NewDate(2021, 1, 2).Value()
+		assert.NoError(t, err)
+		assert.Equal(t, v, driver.Value("2021-01-02"))
+	})
+
+	t.Run("Scan", func(t *testing.T) {
+		var d Date
+		err := d.Scan(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC))
+		assert.NoError(t, err)
+		assert.Equal(t, d, NewDate(2021, 1, 2))
+
+		err = d.Scan([]byte("2021-01-03"))
+		assert.NoError(t, err)
+		assert.Equal(t, d, NewDate(2021, 1, 3))
+
+		err = d.Scan("2021-01-04")
+		assert.NoError(t, err)
+		assert.Equal(t, d, NewDate(2021, 1, 4))
+
+		err = d.Scan(time.Date(2021, 1, 2, 0, 0, 0, 0, time.FixedZone("", 0)))
+		assert.NoError(t, err)
+		assert.Equal(t, d, NewDate(2021, 1, 2))
+
+		err = d.Scan(time.Date(2021, 1, 2, 0, 0, 1, 0, time.UTC))
+		assert.ErrorContains(t, err, "has a time component")
+
+		err = d.Scan(time.Date(2021, 1, 2, 0, 0, 0, 0, time.FixedZone("CET", 3600)))
+		assert.ErrorContains(t, err, "is not in UTC")
+
+		err = d.Scan("2021-01-04 00:00:00")
+		assert.Error(t, err)
+
+		err = d.Scan(nil)
+		assert.ErrorContains(t, err, "cannot be null")
+
+		err = d.Scan(int64(1))
+		assert.ErrorContains(t, err, "cannot be scanned from int64")
+	})
 }
 
 func TestDateTime(t *testing.T) {
@@ -560,6 +1533,47 @@ func TestDateTime(t *testing.T) {
 		assert.False(t, NewDateTime(2021, 1, 1, 0, 0, 0).Equal(NewDateTime(2021, 1, 1, 1, 0, 0)))
 	})
 
+	t.Run("Add", func(t *testing.T) {
+		dt := NewDateTime(2021, 1, 1, 0, 0, 0)
+		assert.Equal(t, dt.Add(NewDuration(0, 0, 0)), dt)
+		assert.Equal(t, dt.Add(NewDuration(25, 1, 1)), NewDateTime(2021, 1, 2, 1, 1, 1))
+		assert.Equal(t, dt.Add(NewDuration(0, 0, -1)), NewDateTime(2020, 12, 31, 23, 59, 59))
+		assert.Equal(t, dt.Add(NewDuration(-1, -30, 0)), NewDateTime(2020, 12, 31, 22, 30, 0))
+	})
+
+	t.Run("Sub", func(t *testing.T) {
+		dt := NewDateTime(2021, 1, 1, 0, 0, 0)
+		assert.Equal(t, dt.Sub(dt), NewDuration(0, 0, 0))
+		assert.Equal(t, NewDateTime(2021, 1, 2, 1, 1, 1).Sub(dt), NewDuration(25, 1, 1))
+		assert.Equal(t, dt.Sub(NewDateTime(2021, 1, 1, 1, 30, 0)), NewDuration(-1, -30, 0))
+	})
+
+	t.Run("AddInterval", func(t *testing.T) {
+		utc := NewTimezone(*time.UTC)
+		loc, err := time.LoadLocation("America/New_York")
+		assert.NoError(t, err)
+		ny := NewTimezone(*loc)
+
+		dt := NewDateTime(2021, 1, 31, 12, 0, 0)
+		assert.Equal(t, dt.AddInterval(NewInterval(0, 0, 0, 0, 0, 0), utc), dt)
+		assert.Equal(t, dt.AddInterval(NewIntervalDate(0, 1, 0), utc), NewDateTime(2021, 2, 28, 12, 0, 0))
+		assert.Equal(t, dt.AddInterval(NewInterval(1, 1, 1, 1, 1, 1), utc), NewDateTime(2022, 3, 1, 13, 1, 1))
+		assert.Equal(t, dt.AddInterval(NewIntervalTime(-12, 0, -1), utc), NewDateTime(2021, 1, 30, 23, 59, 59))
+
+		// 2021-03-14 is the start of DST in New York, 12:00 EST is 17:00 UTC
+		// and 12:00 EDT is 16:00 UTC.
+		dt = NewDateTime(2021, 3, 13, 17, 0, 0)
+		assert.Equal(t, dt.AddInterval(NewIntervalDate(0, 0, 1), ny), NewDateTime(2021, 3, 14, 16, 0, 0))
+		assert.Equal(t, dt.AddInterval(NewIntervalTime(24, 0, 0), ny), NewDateTime(2021, 3, 14, 17, 0, 0))
+		assert.Equal(t, dt.AddInterval(NewIntervalDate(0, 0, 1), utc), NewDateTime(2021, 3, 14, 17, 0, 0))
+
+		// months are added to the local date, 2021-03-01 02:00 UTC
+		// is 2021-02-28 21:00 EST.
+		dt = NewDateTime(2021, 3, 1, 2, 0, 0)
+		assert.Equal(t, dt.AddInterval(NewIntervalDate(0, 1, 0), ny), NewDateTime(2021, 3, 29, 1, 0, 0))
+		assert.Equal(t, dt.AddInterval(NewIntervalDate(0, 1, 0), utc), NewDateTime(2021, 4, 1, 2, 0, 0))
+	})
+
 	t.Run("MarshalJSON", func(t *testing.T) {
 		dt := NewDateTime(2021, 1, 1, 0, 0, 0)
 		out, err := json.Marshal(dt)
@@ -581,4 +1595,49 @@ func TestDateTime(t *testing.T) {
 		err = json.Unmarshal([]byte(`"2021-01-01T00:00"`), &dt)
 		assert.Error(t, err)
 	})
+
+	t.Run("Value", func(t *testing.T) {
+		v, err := NewDateTime(2021, 1, 2, 3, 4, 5).Value()
+		assert.NoError(t, err)
+		assert.Equal(t, v, driver.Value(time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)))
+	})
+
+	t.Run("Scan", func(t *testing.T) {
+		want := NewDateTime(2021, 1, 2, 3, 4, 5)
+
+		tests := []any{
+			time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC),
+			time.Date(2021, 1, 2, 4, 4, 5, 999999999, time.FixedZone("CET", 3600)),
+			"2021-01-02T03:04:05Z",
+			"2021-01-02T05:04:05.123+02:00",
+			"2021-01-02 03:04:05+00",
+			"2021-01-02 08:34:05.123456+05:30",
+			"2021-01-02 03:04:05",
+			[]byte("2021-01-02 03:04:05.5"),
+		}
+		for _, src := range tests {
+			var dt DateTime
+			err := dt.Scan(src)
+			assert.NoError(t, err)
+			assert.Equal(t, dt, want)
+			assert.Equal(t, dt.GoTime().Location(), time.UTC)
+			assert.Equal(t, dt.GoTime().Nanosecond(), 0)
+		}
+
+		var dt DateTime
+		err := dt.Scan("2021-01-02")
+		assert.ErrorContains(t, err, "is invalid")
+
+		err = dt.Scan(nil)
+		assert.ErrorContains(t, err, "cannot be null")
+
+		err = dt.Scan(int64(1))
+		assert.ErrorContains(t, err, "cannot be scanned from int64")
+	})
+}
+
+type testCaseInsensitive struct{}
+
+func (testCaseInsensitive) WeekdayPolicy() WeekdayPolicy {
+	return WeekdayCaseInsensitive
 }
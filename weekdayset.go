@@ -0,0 +1,169 @@
+package timeapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"math/bits"
+	"strings"
+	"time"
+)
+
+// WeekdaySet represents a set of days of the week.
+//
+// The zero value is an empty set. Operations on a set
+// return a new set and never modify the receiver.
+type WeekdaySet struct {
+	// bits has the bit time.Weekday(i) set for each day in the set.
+	bits uint8
+}
+
+// Predefined weekday sets.
+var (
+	// Weekdays is the set of days from Monday to Friday.
+	Weekdays = WeekdaySet{bits: 0b0111110}
+	// Weekend is the set of Saturday and Sunday.
+	Weekend = WeekdaySet{bits: 0b1000001}
+	// EveryDay is the set of all days of the week.
+	EveryDay = WeekdaySet{bits: 0b1111111}
+)
+
+// NewWeekdaySet returns a new WeekdaySet with the given days.
+func NewWeekdaySet(days ...Weekday) WeekdaySet {
+	var s WeekdaySet
+	for _, d := range days {
+		s.bits |= 1 << d.w
+	}
+	return s
+}
+
+// String returns the set in the compact form parsed by ParseWeekdaySet,
+// with the days listed from Monday to Sunday, for example "MO,WE-FR".
+// Runs of three or more consecutive days are written as a range.
+// The empty set is written as an empty string.
+func (s WeekdaySet) String() string {
+	var b strings.Builder
+	days := weekdaysFrom(time.Monday)
+	for i := 0; i < len(days); {
+		if !s.has(days[i]) {
+			i++
+			continue
+		}
+		j := i
+		for j+1 < len(days) && s.has(days[j+1]) {
+			j++
+		}
+		if b.Len() > 0 {
+			b.WriteByte(',')
+		}
+		switch {
+		case j-i >= 2:
+			b.WriteString(weekdayShortNames[days[i]] + "-" + weekdayShortNames[days[j]])
+		case j > i:
+			b.WriteString(weekdayShortNames[days[i]] + "," + weekdayShortNames[days[j]])
+		default:
+			b.WriteString(weekdayShortNames[days[i]])
+		}
+		i = j + 1
+	}
+	return b.String()
+}
+
+// Contains reports whether the day is in the set.
+func (s WeekdaySet) Contains(d Weekday) bool {
+	return s.has(d.w)
+}
+
+func (s WeekdaySet) has(d time.Weekday) bool {
+	return s.bits&(1<<d) != 0
+}
+
+// Add returns the set with the given days added.
+func (s WeekdaySet) Add(days ...Weekday) WeekdaySet {
+	return s.Union(NewWeekdaySet(days...))
+}
+
+// Remove returns the set with the given days removed.
+func (s WeekdaySet) Remove(days ...Weekday) WeekdaySet {
+	return WeekdaySet{bits: s.bits &^ NewWeekdaySet(days...).bits}
+}
+
+// Union returns the set of days in s or in o.
+func (s WeekdaySet) Union(o WeekdaySet) WeekdaySet {
+	return WeekdaySet{bits: s.bits | o.bits}
+}
+
+// Intersect returns the set of days in both s and o.
+func (s WeekdaySet) Intersect(o WeekdaySet) WeekdaySet {
+	return WeekdaySet{bits: s.bits & o.bits}
+}
+
+// Len returns the number of days in the set.
+func (s WeekdaySet) Len() int {
+	return bits.OnesCount8(s.bits)
+}
+
+// IsEmpty reports whether the set has no days.
+func (s WeekdaySet) IsEmpty() bool {
+	return s.bits == 0
+}
+
+// All returns an iterator over the days in the set,
+// in the order of a week starting on weekStart.
+func (s WeekdaySet) All(weekStart Weekday) iter.Seq[Weekday] {
+	return func(yield func(Weekday) bool) {
+		for _, d := range weekdaysFrom(weekStart.w) {
+			if s.has(d) && !yield(Weekday{w: d}) {
+				return
+			}
+		}
+	}
+}
+
+// weekdaysFrom returns the days of a week starting on weekStart.
+func weekdaysFrom(weekStart time.Weekday) [7]time.Weekday {
+	var days [7]time.Weekday
+	for i := range days {
+		days[i] = (weekStart + time.Weekday(i)) % 7
+	}
+	return days
+}
+
+// MarshalJSON encodes the set as an array of weekday names,
+// from Monday to Sunday, for example ["MONDAY","FRIDAY"].
+func (s WeekdaySet) MarshalJSON() ([]byte, error) {
+	names := make([]string, 0, s.Len())
+	for d := range s.All(NewWeekday(time.Monday)) {
+		names = append(names, d.String())
+	}
+	return json.Marshal(names)
+}
+
+// UnmarshalJSON decodes the set from an array of weekday names
+// or from a string in the compact form parsed by ParseWeekdaySet.
+func (s *WeekdaySet) UnmarshalJSON(b []byte) error {
+	if bytes.HasPrefix(b, []byte(`"`)) {
+		var str string
+		if err := json.Unmarshal(b, &str); err != nil {
+			return NewErrJsonValue(err)
+		}
+		set, err := ParseWeekdaySet(str)
+		if err != nil {
+			return NewErrJsonValue(err)
+		}
+		*s = set
+		return nil
+	}
+
+	var days []Weekday
+	if err := json.Unmarshal(b, &days); err != nil {
+		if errors.As(err, &ErrJsonValue{}) {
+			return err
+		}
+		return NewErrJsonValue(fmt.Errorf("weekday set %s is invalid", string(b)))
+	}
+	*s = NewWeekdaySet(days...)
+	return nil
+}
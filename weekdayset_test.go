@@ -0,0 +1,125 @@
+package timeapi
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/krhubert/assert"
+)
+
+func TestWeekdaySet(t *testing.T) {
+	mon := NewWeekday(time.Monday)
+	wed := NewWeekday(time.Wednesday)
+	fri := NewWeekday(time.Friday)
+	sat := NewWeekday(time.Saturday)
+	sun := NewWeekday(time.Sunday)
+
+	t.Run("NewWeekdaySet", func(t *testing.T) {
+		s := NewWeekdaySet(mon, fri, mon)
+		assert.Equal(t, s.Len(), 2)
+		assert.True(t, s.Contains(mon))
+		assert.True(t, s.Contains(fri))
+		assert.False(t, s.Contains(wed))
+		assert.True(t, WeekdaySet{}.IsEmpty())
+		assert.False(t, s.IsEmpty())
+	})
+
+	t.Run("Presets", func(t *testing.T) {
+		assert.Equal(t, Weekdays.Len(), 5)
+		assert.False(t, Weekdays.Contains(sat))
+		assert.Equal(t, Weekend, NewWeekdaySet(sat, sun))
+		assert.Equal(t, Weekdays.Union(Weekend), EveryDay)
+		assert.True(t, Weekdays.Intersect(Weekend).IsEmpty())
+	})
+
+	t.Run("Operations", func(t *testing.T) {
+		s := NewWeekdaySet(mon)
+		assert.Equal(t, s.Add(wed, fri), NewWeekdaySet(mon, wed, fri))
+		assert.Equal(t, s, NewWeekdaySet(mon))
+		assert.Equal(t, Weekdays.Remove(mon, sat), NewWeekdaySet(
+			NewWeekday(time.Tuesday), wed, NewWeekday(time.Thursday), fri,
+		))
+		assert.Equal(t, Weekdays.Union(NewWeekdaySet(sat)).Len(), 6)
+		assert.Equal(t, Weekdays.Intersect(NewWeekdaySet(fri, sat)), NewWeekdaySet(fri))
+	})
+
+	t.Run("All", func(t *testing.T) {
+		s := NewWeekdaySet(sun, mon, sat)
+		assert.Equal(t, slices.Collect(s.All(mon)), []Weekday{mon, sat, sun})
+		assert.Equal(t, slices.Collect(s.All(sun)), []Weekday{sun, mon, sat})
+		assert.Equal(t, slices.Collect(s.All(sat)), []Weekday{sat, sun, mon})
+		assert.Equal(t, len(slices.Collect(WeekdaySet{}.All(mon))), 0)
+	})
+
+	t.Run("String", func(t *testing.T) {
+		tests := []struct {
+			set  WeekdaySet
+			want string
+		}{
+			{WeekdaySet{}, ""},
+			{Weekdays, "MO-FR"},
+			{Weekend, "SA,SU"},
+			{EveryDay, "MO-SU"},
+			{NewWeekdaySet(mon, wed, fri), "MO,WE,FR"},
+			{NewWeekdaySet(mon, wed, NewWeekday(time.Thursday), fri, sun), "MO,WE-FR,SU"},
+		}
+		for _, tt := range tests {
+			assert.Equal(t, tt.set.String(), tt.want)
+			set, err := ParseWeekdaySet(tt.want)
+			assert.NoError(t, err)
+			assert.Equal(t, set, tt.set)
+		}
+	})
+
+	t.Run("ParseWeekdaySet", func(t *testing.T) {
+		tests := []struct {
+			s    string
+			want WeekdaySet
+		}{
+			{"MO,TU-FR", Weekdays},
+			{"MONDAY,FRIDAY", NewWeekdaySet(mon, fri)},
+			{"SA-SUNDAY", Weekend},
+			{"FR-MO", NewWeekdaySet(fri, sat, sun, mon)},
+			{"WE-WE", NewWeekdaySet(wed)},
+			{"MO,MO", NewWeekdaySet(mon)},
+		}
+		for _, tt := range tests {
+			set, err := ParseWeekdaySet(tt.s)
+			assert.NoError(t, err)
+			assert.Equal(t, set, tt.want)
+		}
+
+		for _, s := range []string{"mo", "MON", "MO,", ",MO", "MO-", "MO-TU-WE", "MO TU"} {
+			_, err := ParseWeekdaySet(s)
+			assert.ErrorContains(t, err, "invalid weekday set")
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		out, err := json.Marshal(NewWeekdaySet(fri, mon))
+		assert.NoError(t, err)
+		assert.Equal(t, string(out), `["MONDAY","FRIDAY"]`)
+
+		out, err = json.Marshal(WeekdaySet{})
+		assert.NoError(t, err)
+		assert.Equal(t, string(out), `[]`)
+
+		var s WeekdaySet
+		err = json.Unmarshal([]byte(`["FRIDAY","MONDAY","FRIDAY"]`), &s)
+		assert.NoError(t, err)
+		assert.Equal(t, s, NewWeekdaySet(mon, fri))
+
+		err = json.Unmarshal([]byte(`"MO,TU-FR"`), &s)
+		assert.NoError(t, err)
+		assert.Equal(t, s, Weekdays)
+
+		err = json.Unmarshal([]byte(`["MO"]`), &s)
+		assert.ErrorContains(t, err, `weekday invalid value "MO"`)
+		err = json.Unmarshal([]byte(`"MO-"`), &s)
+		assert.ErrorContains(t, err, "invalid weekday set")
+		err = json.Unmarshal([]byte(`1`), &s)
+		assert.ErrorContains(t, err, "weekday set 1 is invalid")
+	})
+}